@@ -3,6 +3,11 @@ package api
 import (
 	_ "crud/api/docs"
 	"crud/api/handler"
+	"crud/config"
+	"crud/pkg/media"
+	"crud/pkg/outbox"
+	"crud/pkg/ratelimit"
+	"crud/pkg/rbac"
 	"crud/storage"
 
 	"github.com/gin-gonic/gin"
@@ -10,27 +15,163 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetUpApi(r *gin.Engine, storage storage.StorageI) {
+func SetUpApi(r *gin.Engine, cfg config.Config, storage storage.StorageI, policy rbac.Policy, outbox outbox.Sink, limiter ratelimit.Store, blobs media.BlobStore) {
 
-	handlerV1 := handler.NewHandlerV1(storage)
+	handlerV1 := handler.NewHandlerV1(cfg, storage, policy, outbox, limiter, blobs)
 
-	r.POST("/film", handlerV1.CreateFilm)
-	r.GET("/film/:id", handlerV1.GetFilmById)
-	r.GET("/film", handlerV1.GetFilmList)
-	r.PUT("/film/:id", handlerV1.UpdateFilm)
-	r.DELETE("/film/:id", handlerV1.DeleteFilm)
+	// The local blob store only writes the files, MediaBaseURL has to point
+	// here.
+	if cfg.MediaStorage == "local" {
+		r.Static("/media", cfg.MediaDir)
+	}
 
-	r.POST("/actor", handlerV1.CreateActor)
-	r.GET("/actor/:id", handlerV1.GetActorById)
-	r.GET("/actor", handlerV1.GetActorList)
-	r.PUT("/actor/:id", handlerV1.UpdateActor)
-	r.DELETE("/actor/:id", handlerV1.DeleteActor)
+	auth := r.Group("/auth", handlerV1.RateLimit("auth"))
+	auth.POST("/register", handlerV1.Register)
+	auth.POST("/login", handlerV1.Login)
+	auth.POST("/refresh", handlerV1.RefreshToken)
+	auth.POST("/logout", handlerV1.Logout)
+	auth.POST("/password/forgot", handlerV1.ForgotPassword)
+	auth.POST("/password/reset", handlerV1.ResetPassword)
 
-	r.POST("/category", handlerV1.CreateCategory)
-	r.GET("/category/:id", handlerV1.GetCategoryById)
-	r.GET("/category", handlerV1.GetCategoryList)
-	r.PUT("/category/:id", handlerV1.UpdateCategory)
-	r.DELETE("/category/:id", handlerV1.DeleteCategory)
+	film := r.Group("/film", handlerV1.Authenticate(), handlerV1.RateLimit("film"), handlerV1.Authorize("film"))
+	film.POST("", handlerV1.Idempotency(), handlerV1.CreateFilm)
+	film.GET("/:id", handlerV1.GetFilmById)
+	film.GET("/export", handlerV1.ExportFilm)
+	film.GET("", handlerV1.GetFilmList)
+	film.PUT("/:id", handlerV1.UpdateFilm)
+	film.PUT("/by-title-year", handlerV1.UpsertFilm)
+	film.DELETE("/:id", handlerV1.DeleteFilm)
+	film.POST("/batch", handlerV1.Idempotency(), handlerV1.CreateFilmBatch)
+	film.POST("/batch/delete", handlerV1.Require("film:delete"), handlerV1.DeleteFilmBatch)
+	film.POST("/import", handlerV1.ImportFilm)
+	film.GET("/:id/actors", handlerV1.GetFilmActors)
+	film.PUT("/:id/actors/:actor_id", handlerV1.AddFilmActor)
+	film.DELETE("/:id/actors/:actor_id", handlerV1.RemoveFilmActor)
+	film.GET("/:id/categories", handlerV1.GetFilmCategories)
+	film.PUT("/:id/categories/:category_id", handlerV1.AddFilmCategory)
+	film.DELETE("/:id/categories/:category_id", handlerV1.RemoveFilmCategory)
+	film.GET("/:id/inventory", handlerV1.GetFilmInventory)
+	film.GET("/:id/similar", handlerV1.GetSimilarFilms)
+	film.POST("/:id/poster", handlerV1.UploadFilmPoster)
+	film.POST("/:id/trailer", handlerV1.UploadFilmTrailer)
+
+	filmReview := r.Group("/film/:id/reviews", handlerV1.Authenticate(), handlerV1.RateLimit("review"), handlerV1.Authorize("review"))
+	filmReview.POST("", handlerV1.Idempotency(), handlerV1.CreateFilmReview)
+	filmReview.GET("", handlerV1.GetFilmReviews)
+
+	review := r.Group("/review", handlerV1.Authenticate(), handlerV1.RateLimit("review"), handlerV1.Authorize("review"))
+	review.GET("/:id", handlerV1.GetReviewById)
+	review.PUT("/:id/status", handlerV1.Require("review:moderate"), handlerV1.UpdateReviewStatus)
+	review.PUT("/:id/helpful", handlerV1.VoteReview)
+
+	actor := r.Group("/actor", handlerV1.Authenticate(), handlerV1.RateLimit("actor"), handlerV1.Authorize("actor"))
+	actor.POST("", handlerV1.Idempotency(), handlerV1.CreateActor)
+	actor.GET("/:id", handlerV1.GetActorById)
+	actor.GET("/export", handlerV1.ExportActor)
+	actor.GET("/suggest", handlerV1.SuggestActor)
+	actor.GET("", handlerV1.GetActorList)
+	actor.PUT("/:id", handlerV1.UpdateActor)
+	actor.PUT("/by-name", handlerV1.UpsertActor)
+	actor.DELETE("/:id", handlerV1.DeleteActor)
+	actor.POST("/batch", handlerV1.Idempotency(), handlerV1.CreateActorBatch)
+	actor.POST("/batch/delete", handlerV1.Require("actor:delete"), handlerV1.DeleteActorBatch)
+	actor.POST("/import", handlerV1.ImportActor)
+	actor.POST("/:id/merge", handlerV1.Require("actor:delete"), handlerV1.MergeActor)
+	actor.POST("/:id/photo", handlerV1.UploadActorPhoto)
+
+	category := r.Group("/category", handlerV1.Authenticate(), handlerV1.RateLimit("category"), handlerV1.Authorize("category"))
+	category.POST("", handlerV1.Idempotency(), handlerV1.CreateCategory)
+	category.GET("/:id", handlerV1.GetCategoryById)
+	category.GET("/:id/descendants", handlerV1.GetCategoryDescendants)
+	category.GET("/tree", handlerV1.GetCategoryTree)
+	category.GET("/export", handlerV1.ExportCategory)
+	category.GET("", handlerV1.GetCategoryList)
+	category.PUT("/:id", handlerV1.UpdateCategory)
+	category.PUT("/by-name", handlerV1.UpsertCategory)
+	category.DELETE("/:id", handlerV1.DeleteCategory)
+	category.POST("/batch", handlerV1.Idempotency(), handlerV1.CreateCategoryBatch)
+	category.POST("/batch/delete", handlerV1.Require("category:delete"), handlerV1.DeleteCategoryBatch)
+	category.POST("/import", handlerV1.ImportCategory)
+
+	language := r.Group("/language", handlerV1.Authenticate(), handlerV1.RateLimit("language"), handlerV1.Authorize("language"))
+	language.POST("", handlerV1.Idempotency(), handlerV1.CreateLanguage)
+	language.GET("/:id", handlerV1.GetLanguageById)
+	language.GET("", handlerV1.GetLanguageList)
+	language.PUT("/:id", handlerV1.UpdateLanguage)
+	language.DELETE("/:id", handlerV1.DeleteLanguage)
+
+	store := r.Group("/store", handlerV1.Authenticate(), handlerV1.RateLimit("store"), handlerV1.Authorize("store"))
+	store.POST("", handlerV1.Idempotency(), handlerV1.CreateStore)
+	store.GET("/:id", handlerV1.GetStoreById)
+	store.GET("/:id/inventory", handlerV1.GetStoreInventory)
+	store.GET("", handlerV1.GetStoreList)
+	store.PUT("/:id", handlerV1.UpdateStore)
+	store.DELETE("/:id", handlerV1.DeleteStore)
+
+	inventory := r.Group("/inventory", handlerV1.Authenticate(), handlerV1.RateLimit("inventory"), handlerV1.Authorize("inventory"))
+	inventory.POST("", handlerV1.Idempotency(), handlerV1.CreateInventory)
+	inventory.GET("/:id", handlerV1.GetInventoryById)
+	inventory.GET("", handlerV1.GetInventoryList)
+	inventory.PUT("/:id", handlerV1.UpdateInventory)
+	inventory.DELETE("/:id", handlerV1.DeleteInventory)
+
+	country := r.Group("/country", handlerV1.Authenticate(), handlerV1.RateLimit("country"), handlerV1.Authorize("country"))
+	country.POST("", handlerV1.Idempotency(), handlerV1.CreateCountry)
+	country.GET("/:id", handlerV1.GetCountryById)
+	country.GET("", handlerV1.GetCountryList)
+	country.PUT("/:id", handlerV1.UpdateCountry)
+	country.DELETE("/:id", handlerV1.DeleteCountry)
+
+	city := r.Group("/city", handlerV1.Authenticate(), handlerV1.RateLimit("city"), handlerV1.Authorize("city"))
+	city.POST("", handlerV1.Idempotency(), handlerV1.CreateCity)
+	city.GET("/:id", handlerV1.GetCityById)
+	city.GET("", handlerV1.GetCityList)
+	city.PUT("/:id", handlerV1.UpdateCity)
+	city.DELETE("/:id", handlerV1.DeleteCity)
+
+	customer := r.Group("/customer", handlerV1.Authenticate(), handlerV1.RateLimit("customer"), handlerV1.Authorize("customer"))
+	customer.POST("", handlerV1.Idempotency(), handlerV1.CreateCustomer)
+	customer.GET("/:id", handlerV1.GetCustomerById)
+	customer.GET("/:id/rentals", handlerV1.GetCustomerRentals)
+	customer.GET("/:id/balance", handlerV1.GetCustomerBalance)
+	customer.GET("/:id/recommendations", handlerV1.GetCustomerRecommendations)
+	customer.GET("", handlerV1.GetCustomerList)
+	customer.PUT("/:id", handlerV1.UpdateCustomer)
+	customer.DELETE("/:id", handlerV1.DeleteCustomer)
+
+	staff := r.Group("/staff", handlerV1.Authenticate(), handlerV1.RateLimit("staff"), handlerV1.Authorize("staff"))
+	staff.POST("", handlerV1.Idempotency(), handlerV1.CreateStaff)
+	staff.GET("/:id", handlerV1.GetStaffById)
+	staff.GET("", handlerV1.GetStaffList)
+	staff.PUT("/:id", handlerV1.UpdateStaff)
+	staff.DELETE("/:id", handlerV1.DeleteStaff)
+
+	rental := r.Group("/rental", handlerV1.Authenticate(), handlerV1.RateLimit("rental"), handlerV1.Authorize("rental"))
+	rental.POST("", handlerV1.RequireStaff(), handlerV1.Idempotency(), handlerV1.CreateRental)
+	rental.GET("/:id", handlerV1.GetRentalById)
+	rental.GET("", handlerV1.GetRentalList)
+	rental.POST("/:id/return", handlerV1.RequireStaff(), handlerV1.Idempotency(), handlerV1.ReturnRental)
+
+	payment := r.Group("/payment", handlerV1.Authenticate(), handlerV1.RateLimit("payment"), handlerV1.Authorize("payment"))
+	payment.POST("", handlerV1.RequireStaff(), handlerV1.Idempotency(), handlerV1.CreatePayment)
+	payment.GET("/:id", handlerV1.GetPaymentById)
+	payment.GET("", handlerV1.GetPaymentList)
+	payment.POST("/:id/refund", handlerV1.Require("payment:admin"), handlerV1.RequireStaff(), handlerV1.Idempotency(), handlerV1.RefundPayment)
+
+	reports := r.Group("/reports", handlerV1.Authenticate(), handlerV1.RateLimit("reports"), handlerV1.Authorize("reports"))
+	reports.GET("/top-films", handlerV1.GetTopFilmsReport)
+	reports.GET("/revenue-by-category", handlerV1.GetCategoryRevenueReport)
+	reports.GET("/actor-popularity", handlerV1.GetActorPopularityReport)
+	reports.GET("/store-revenue", handlerV1.GetStoreRevenueReport)
+
+	search := r.Group("/search", handlerV1.Authenticate(), handlerV1.RateLimit("search"))
+	search.GET("", handlerV1.Search)
+
+	apiKey := r.Group("/api-key", handlerV1.Authenticate(), handlerV1.RateLimit("api_key"), handlerV1.Require("api_key:admin"))
+	apiKey.POST("", handlerV1.CreateApiKey)
+	apiKey.GET("/:id", handlerV1.GetApiKeyById)
+	apiKey.GET("", handlerV1.GetApiKeyList)
+	apiKey.DELETE("/:id", handlerV1.RevokeApiKey)
 
 	url := ginSwagger.URL("swagger/doc.json") // The url pointing to API definition
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
@@ -6,23 +6,29 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"crud/models"
+	"crud/storage"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
 )
 
 // CreateActor godoc
 // @ID create_actor
 // @Router /actor [POST]
 // @Summary Create Actor
-// @Description Create Actor
+// @Description Create Actor. Responds 409 with the similar actors when a near duplicate exists, unless force is true.
 // @Tags Actor
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param force query bool false "create even if a similar actor exists"
 // @Param actor body models.CreateActor true "CreateActorRequestBody"
 // @Success 201 {object} models.Actor "GetactorBody"
 // @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} models.ActorDuplicateWarning "Similar Actor Exists, or a string when an external id is taken"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) CreateActor(c *gin.Context) {
 	var actor models.CreateActor
@@ -34,7 +40,41 @@ func (h *HandlerV1) CreateActor(c *gin.Context) {
 		return
 	}
 
+	err = actor.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if c.Query("force") != "true" {
+		similar, err := h.storage.Actor().FindSimilar(
+			context.Background(),
+			actor.First_name+" "+actor.Last_name,
+			h.cfg.ActorDuplicateThreshold,
+			5,
+		)
+
+		if err != nil {
+			log.Printf("error whiling FindSimilar: %v\n", err)
+			c.JSON(http.StatusInternalServerError, errors.New("error whiling FindSimilar").Error())
+			return
+		}
+
+		if len(similar) > 0 {
+			c.JSON(http.StatusConflict, models.ActorDuplicateWarning{
+				Message: "a similar actor already exists, retry with force=true to create anyway",
+				Similar: similar,
+			})
+			return
+		}
+	}
+
 	id, err := h.storage.Actor().Create(context.Background(), &actor)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("actor with the same external id already exists").Error())
+		return
+	}
+
 	if err != nil {
 		log.Printf("error whiling Create: %v\n", err)
 		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
@@ -65,7 +105,9 @@ func (h *HandlerV1) CreateActor(c *gin.Context) {
 // @Produce json
 // @Param id path string true "id"
 // @Success 200 {object} models.Actor "GetActorBody"
+// @Response 301 {string} string "Actor was merged, Location points to the survivor"
 // @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) GetActorById(c *gin.Context) {
 
@@ -76,6 +118,23 @@ func (h *HandlerV1) GetActorById(c *gin.Context) {
 		&models.ActorPrimarKey{Id: id},
 	)
 
+	if errors.Is(err, pgx.ErrNoRows) {
+		survivorId, err := h.storage.Actor().GetMergedInto(
+			context.Background(),
+			&models.ActorPrimarKey{Id: id},
+		)
+
+		if err == nil {
+			c.Redirect(http.StatusMovedPermanently, "/actor/"+survivorId)
+			return
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, errors.New("actor not found").Error())
+			return
+		}
+	}
+
 	if err != nil {
 		log.Printf("error whiling GetByPKey: %v\n", err)
 		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
@@ -95,51 +154,93 @@ func (h *HandlerV1) GetActorById(c *gin.Context) {
 // @Produce json
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
+// @Param birth_year query int false "birth_year"
 // @Success 200 {object} models.GetListActorResponse "GetActorBody"
 // @Response 400 {object} string "Invalid Argument"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) GetActorList(c *gin.Context) {
-	var (
-		limit  int
-		offset int
-		err    error
-	)
 
-	limitStr := c.Query("limit")
-	if limitStr != "" {
-		limit, err = strconv.Atoi(limitStr)
-		if err != nil {
-			log.Printf("error whiling limit: %v\n", err)
-			c.JSON(http.StatusBadRequest, err.Error())
-			return
-		}
+	req, err := getActorListRequest(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
 	}
 
-	offsetStr := c.Query("offset")
-	if offsetStr != "" {
-		offset, err = strconv.Atoi(offsetStr)
-		if err != nil {
-			log.Printf("error whiling limit: %v\n", err)
-			c.JSON(http.StatusBadRequest, err.Error())
-			return
+	resp, err := h.storage.Actor().GetList(context.Background(), req)
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getActorListRequest parses the query shared by the list and export endpoints.
+func getActorListRequest(c *gin.Context) (*models.GetListActorRequest, error) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &models.GetListActorRequest{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if value := c.Query("birth_year"); value != "" {
+		year, err := strconv.Atoi(value)
+		if err != nil || year < 1 || year > 9999 {
+			return nil, errors.New("birth_year must be a year")
 		}
+		req.BirthYear = int32(year)
 	}
 
-	resp, err := h.storage.Actor().GetList(
-		context.Background(),
-		&models.GetListActorRequest{
-			Limit:  int32(limit),
-			Offset: int32(offset),
-		},
-	)
+	return req, nil
+}
 
+// SuggestActor godoc
+// @ID suggest_actor
+// @Router /actor/suggest [GET]
+// @Summary Suggest Actor
+// @Description Typo tolerant actor name lookup for autocomplete
+// @Tags Actor
+// @Accept json
+// @Produce json
+// @Param q query string true "name"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.SuggestActorResponse "SuggestActorBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) SuggestActor(c *gin.Context) {
+
+	name := strings.TrimSpace(c.Query("q"))
+	if name == "" {
+		c.JSON(http.StatusBadRequest, errors.New("required q").Error())
+		return
+	}
+
+	limit, _, err := getPagination(c)
 	if err != nil {
-		log.Printf("error whiling get list: %v", err)
-		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		log.Printf("error whiling limit: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	if limit <= 0 {
+		limit = 10
+	}
+
+	resp, err := h.storage.Actor().FindSimilar(context.Background(), name, h.cfg.ActorSuggestThreshold, limit)
+	if err != nil {
+		log.Printf("error whiling FindSimilar: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling FindSimilar").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuggestActorResponse{Actors: resp})
 }
 
 // UpdateActor godoc
@@ -154,6 +255,7 @@ func (h *HandlerV1) GetActorList(c *gin.Context) {
 // @Param actor body models.UpdateActor true "CreateActorRequestBody"
 // @Success 200 {object} models.Actor "GetactorsBody"
 // @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Already Exists"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) UpdateActor(c *gin.Context) {
 
@@ -176,12 +278,23 @@ func (h *HandlerV1) UpdateActor(c *gin.Context) {
 		return
 	}
 
+	err = actor.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
 	rowsAffected, err := h.storage.Actor().Update(
 		context.Background(),
 		id,
 		&actor,
 	)
 
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("actor with the same external id already exists").Error())
+		return
+	}
+
 	if err != nil {
 		log.Printf("error whiling update: %v", err)
 		c.JSON(http.StatusInternalServerError, errors.New("error whiling update").Error())
@@ -208,6 +321,67 @@ func (h *HandlerV1) UpdateActor(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// UpsertActor godoc
+// @ID upsert_actor
+// @Router /actor/by-name [PUT]
+// @Summary Upsert Actor
+// @Description Create the actor or update the one matched by first_name and last_name. Actor names are not unique: when several actors share the name the oldest one is updated, merge the duplicates to choose another. Responds 201 when created and 200 when updated.
+// @Tags Actor
+// @Accept json
+// @Produce json
+// @Param actor body models.CreateActor true "CreateActorRequestBody"
+// @Success 200 {object} models.Actor "GetActorBody"
+// @Success 201 {object} models.Actor "GetActorBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Already Exists"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UpsertActor(c *gin.Context) {
+	var actor models.CreateActor
+
+	err := c.ShouldBindJSON(&actor)
+	if err != nil {
+		log.Printf("error whiling upsert: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = actor.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, created, err := h.storage.Actor().Upsert(context.Background(), &actor)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("actor with the same external id already exists").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Upsert: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Upsert").Error())
+		return
+	}
+
+	resp, err := h.storage.Actor().GetByPKey(
+		context.Background(),
+		&models.ActorPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, resp)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // DeleteByIdActor godoc
 // @ID delete_by_id_actor
 // @Router /actor/{id} [DELETE]
@@ -229,7 +403,7 @@ func (h *HandlerV1) DeleteActor(c *gin.Context) {
 		return
 	}
 
-	err := h.storage.Actor().Delete(
+	assets, err := h.storage.Actor().Delete(
 		context.Background(),
 		&models.ActorPrimarKey{
 			Id: id,
@@ -242,5 +416,182 @@ func (h *HandlerV1) DeleteActor(c *gin.Context) {
 		return
 	}
 
+	h.deleteAssets(assets)
+
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// CreateActorBatch godoc
+// @ID create_actor_batch
+// @Router /actor/batch [POST]
+// @Summary Create Actor Batch
+// @Description Create many Actor items in one transaction. all_or_nothing mode (default) rolls back on any failure, best_effort mode reports failures per item.
+// @Tags Actor
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param batch body models.CreateActorBatch true "CreateActorBatchRequestBody"
+// @Success 201 {object} models.BatchResponse "BatchBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 422 {object} models.BatchResponse "BatchBody"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateActorBatch(c *gin.Context) {
+	var batch models.CreateActorBatch
+
+	err := c.ShouldBindJSON(&batch)
+	if err != nil {
+		log.Printf("error whiling create batch: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.checkBatch(&batch.Mode, len(batch.Items))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	valid, results := validateBatch(batch.Mode, len(batch.Items), func(i int) error {
+		if batch.Items[i] == nil {
+			return errors.New("empty item")
+		}
+		return batch.Items[i].Validate()
+	})
+
+	if len(valid) > 0 {
+		items := make([]*models.CreateActor, 0, len(valid))
+		for _, i := range valid {
+			items = append(items, batch.Items[i])
+		}
+
+		stored, err := h.storage.Actor().CreateMany(context.Background(), items, batch.Mode)
+		if err != nil {
+			log.Printf("error whiling CreateMany: %v\n", err)
+			c.JSON(http.StatusInternalServerError, errors.New("error whiling CreateMany").Error())
+			return
+		}
+
+		mergeBatch(results, valid, stored)
+	}
+
+	resp := batchResponse(batch.Mode, results)
+	if resp.Succeeded == 0 {
+		c.JSON(http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// DeleteActorBatch godoc
+// @ID delete_actor_batch
+// @Router /actor/batch/delete [POST]
+// @Summary Delete Actor Batch
+// @Description Delete many Actor items by id in one transaction. Malformed, missing and still referenced ids fail the batch in all_or_nothing mode (default).
+// @Tags Actor
+// @Accept json
+// @Produce json
+// @Param batch body models.DeleteManyRequest true "DeleteManyRequestBody"
+// @Success 200 {object} models.BatchResponse "BatchBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 422 {object} models.BatchResponse "BatchBody"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) DeleteActorBatch(c *gin.Context) {
+	var batch models.DeleteManyRequest
+
+	err := c.ShouldBindJSON(&batch)
+	if err != nil {
+		log.Printf("error whiling delete batch: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.checkBatch(&batch.Mode, len(batch.Ids))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, assets, err := h.storage.Actor().DeleteMany(context.Background(), batch.Ids, batch.Mode)
+	if err != nil {
+		log.Printf("error whiling DeleteMany: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling DeleteMany").Error())
+		return
+	}
+
+	h.deleteAssets(assets)
+
+	resp := batchResponse(batch.Mode, results)
+	if resp.Succeeded == 0 {
+		c.JSON(http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// MergeActor godoc
+// @ID merge_actor
+// @Router /actor/{id}/merge [POST]
+// @Summary Merge Actor
+// @Description Merge the duplicate actor {id} into the survivor. Film links move to the survivor, profile fields and external ids the survivor lacks are copied from the duplicate, the merge is recorded and GET /actor/{id} redirects to the survivor afterwards.
+// @Tags Actor
+// @Accept json
+// @Produce json
+// @Param id path string true "duplicate actor id"
+// @Param merge body models.MergeActor true "MergeActorRequestBody"
+// @Success 200 {object} models.MergeActorResponse "MergeActorBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) MergeActor(c *gin.Context) {
+	var (
+		req      models.MergeActor
+		resp     models.MergeActorResponse
+		orphaned []*models.MediaAsset
+	)
+
+	id := c.Param("id")
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("error whiling merge: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.SurvivorId == "" || req.SurvivorId == id {
+		c.JSON(http.StatusBadRequest, errors.New("survivor_id is required and must differ from the merged actor").Error())
+		return
+	}
+
+	err = h.storage.WithTx(context.Background(), func(tx storage.StorageI) error {
+
+		resp.Merge, orphaned, err = tx.Actor().Merge(context.Background(), id, &req, getIdentity(c).Subject)
+		if err != nil {
+			return err
+		}
+
+		resp.Survivor, err = tx.Actor().GetByPKey(
+			context.Background(),
+			&models.ActorPrimarKey{Id: req.SurvivorId},
+		)
+
+		return err
+	})
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("actor not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling merge: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling merge").Error())
+		return
+	}
+
+	h.deleteAssets(orphaned)
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"crud/models"
+	"crud/pkg/security"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateApiKey godoc
+// @ID create_api_key
+// @Router /api-key [POST]
+// @Summary Create Api Key
+// @Description Create Api Key. The plaintext key is returned only once and is never stored for an idempotent replay.
+// @Tags ApiKey
+// @Accept json
+// @Produce json
+// @Param api_key body models.CreateApiKey true "CreateApiKeyRequestBody"
+// @Success 201 {object} models.CreateApiKeyResponse "CreateApiKeyBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateApiKey(c *gin.Context) {
+	var apiKey models.CreateApiKey
+
+	err := c.ShouldBindJSON(&apiKey)
+	if err != nil {
+		log.Printf("error whiling create: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if apiKey.Name == "" {
+		c.JSON(http.StatusBadRequest, errors.New("required api key name").Error())
+		return
+	}
+
+	key, prefix, hash, err := security.GenerateApiKey()
+	if err != nil {
+		log.Printf("error whiling generate api key: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling generate api key").Error())
+		return
+	}
+
+	apiKey.KeyPrefix = prefix
+	apiKey.KeyHash = hash
+
+	id, err := h.storage.ApiKey().Create(context.Background(), &apiKey)
+	if err != nil {
+		log.Printf("error whiling Create: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
+		return
+	}
+
+	resp, err := h.storage.ApiKey().GetByPKey(
+		context.Background(),
+		&models.ApiKeyPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.CreateApiKeyResponse{
+		Key:    key,
+		ApiKey: resp,
+	})
+}
+
+// GetByIdApiKey godoc
+// @ID get_by_id_api_key
+// @Router /api-key/{id} [GET]
+// @Summary Get By Id Api Key
+// @Description Get By Id Api Key
+// @Tags ApiKey
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.ApiKey "GetApiKeyBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetApiKeyById(c *gin.Context) {
+
+	id := c.Param("id")
+
+	resp, err := h.storage.ApiKey().GetByPKey(
+		context.Background(),
+		&models.ApiKeyPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetListApiKey godoc
+// @ID get_list_api_key
+// @Router /api-key [GET]
+// @Summary Get List Api Key
+// @Description Get List Api Key
+// @Tags ApiKey
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.GetListApiKeyResponse "GetApiKeyBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetApiKeyList(c *gin.Context) {
+	var (
+		limit  int
+		offset int
+		err    error
+	)
+
+	limitStr := c.Query("limit")
+	if limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			log.Printf("error whiling limit: %v\n", err)
+			c.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	offsetStr := c.Query("offset")
+	if offsetStr != "" {
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil {
+			log.Printf("error whiling offset: %v\n", err)
+			c.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	resp, err := h.storage.ApiKey().GetList(
+		context.Background(),
+		&models.GetListApiKeyRequest{
+			Limit:  int32(limit),
+			Offset: int32(offset),
+		},
+	)
+
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevokeApiKey godoc
+// @ID revoke_api_key
+// @Router /api-key/{id} [DELETE]
+// @Summary Revoke Api Key
+// @Description Revoke Api Key
+// @Tags ApiKey
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) RevokeApiKey(c *gin.Context) {
+
+	id := c.Param("id")
+	if id == "" {
+		log.Printf("error whiling revoke: %v\n", errors.New("required api key id").Error())
+		c.JSON(http.StatusBadRequest, errors.New("required api key id").Error())
+		return
+	}
+
+	rowsAffected, err := h.storage.ApiKey().Revoke(
+		context.Background(),
+		&models.ApiKeyPrimarKey{
+			Id: id,
+		},
+	)
+
+	if err != nil {
+		log.Printf("error whiling revoke: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling revoke").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("api key not found or already revoked").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
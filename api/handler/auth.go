@@ -0,0 +1,401 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/pkg/outbox"
+	"crud/pkg/security"
+	"crud/storage"
+)
+
+const minPasswordLength = 8
+
+// Register godoc
+// @ID register
+// @Router /auth/register [POST]
+// @Summary Register
+// @Description Register a new user and return access and refresh tokens
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param user body models.CreateUser true "RegisterRequestBody"
+// @Success 201 {object} models.AuthResponse "AuthBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Already Exists"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) Register(c *gin.Context) {
+	var user models.CreateUser
+
+	err := c.ShouldBindJSON(&user)
+	if err != nil {
+		log.Printf("error whiling register: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !strings.Contains(user.Email, "@") {
+		c.JSON(http.StatusBadRequest, errors.New("invalid email").Error())
+		return
+	}
+
+	if len(user.Password) < minPasswordLength {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("password must be at least %d characters", minPasswordLength))
+		return
+	}
+
+	user.PasswordHash, err = security.HashPassword(user.Password)
+	if err != nil {
+		log.Printf("error whiling hash password: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling hash password").Error())
+		return
+	}
+
+	id, err := h.storage.User().Create(context.Background(), &user)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("email already registered").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Create: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
+		return
+	}
+
+	resp, err := h.storage.User().GetByPKey(
+		context.Background(),
+		&models.UserPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	tokens, err := h.issueTokens(resp)
+	if err != nil {
+		log.Printf("error whiling issue tokens: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling issue tokens").Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.AuthResponse{
+		User:   resp,
+		Tokens: tokens,
+	})
+}
+
+// Login godoc
+// @ID login
+// @Router /auth/login [POST]
+// @Summary Login
+// @Description Login with email and password
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param login body models.LoginRequest true "LoginRequestBody"
+// @Success 200 {object} models.AuthResponse "AuthBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 401 {object} string "Unauthorized"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) Login(c *gin.Context) {
+	var login models.LoginRequest
+
+	err := c.ShouldBindJSON(&login)
+	if err != nil {
+		log.Printf("error whiling login: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.storage.User().GetByEmail(context.Background(), login.Email)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		log.Printf("error whiling GetByEmail: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByEmail").Error())
+		return
+	}
+
+	if err != nil || !security.CheckPassword(user.PasswordHash, login.Password) {
+		c.JSON(http.StatusUnauthorized, errors.New("invalid email or password").Error())
+		return
+	}
+
+	tokens, err := h.issueTokens(user)
+	if err != nil {
+		log.Printf("error whiling issue tokens: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling issue tokens").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AuthResponse{
+		User:   user,
+		Tokens: tokens,
+	})
+}
+
+// RefreshToken godoc
+// @ID refresh_token
+// @Router /auth/refresh [POST]
+// @Summary Refresh Token
+// @Description Exchange a refresh token for a new token pair. The presented refresh token is revoked.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param refresh body models.RefreshTokenRequest true "RefreshTokenRequestBody"
+// @Success 200 {object} models.TokenResponse "TokenBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 401 {object} string "Unauthorized"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) RefreshToken(c *gin.Context) {
+	var req models.RefreshTokenRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("error whiling refresh: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	refreshToken, refreshHash, err := security.GenerateToken()
+	if err != nil {
+		log.Printf("error whiling generate token: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling generate token").Error())
+		return
+	}
+
+	userId, err := h.storage.RefreshToken().Rotate(
+		context.Background(),
+		security.HashToken(req.RefreshToken),
+		refreshHash,
+		h.cfg.RefreshTokenTTL,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, storage.ErrTokenExpired) || errors.Is(err, storage.ErrTokenReused) {
+		c.JSON(http.StatusUnauthorized, errors.New("invalid refresh token").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling rotate: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling rotate").Error())
+		return
+	}
+
+	user, err := h.storage.User().GetByPKey(
+		context.Background(),
+		&models.UserPrimarKey{Id: userId},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	accessToken, err := security.GenerateJWT(user.Id, user.Roles, h.cfg.AccessTokenTTL, h.cfg.SecretKey)
+	if err != nil {
+		log.Printf("error whiling generate jwt: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling generate jwt").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(h.cfg.AccessTokenTTL.Seconds()),
+	})
+}
+
+// Logout godoc
+// @ID logout
+// @Router /auth/logout [POST]
+// @Summary Logout
+// @Description Revoke a refresh token
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param refresh body models.RefreshTokenRequest true "RefreshTokenRequestBody"
+// @Success 204
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) Logout(c *gin.Context) {
+	var req models.RefreshTokenRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("error whiling logout: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.storage.RefreshToken().Revoke(context.Background(), security.HashToken(req.RefreshToken))
+	if err != nil {
+		log.Printf("error whiling revoke: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling revoke").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// ForgotPassword godoc
+// @ID forgot_password
+// @Router /auth/password/forgot [POST]
+// @Summary Forgot Password
+// @Description Send a password reset token to the outbox. Always succeeds so that registered emails can not be enumerated.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param forgot body models.ForgotPasswordRequest true "ForgotPasswordRequestBody"
+// @Success 202
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) ForgotPassword(c *gin.Context) {
+	var req models.ForgotPasswordRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("error whiling forgot password: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.storage.User().GetByEmail(context.Background(), req.Email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusAccepted, nil)
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByEmail: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByEmail").Error())
+		return
+	}
+
+	token, hash, err := security.GenerateToken()
+	if err != nil {
+		log.Printf("error whiling generate token: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling generate token").Error())
+		return
+	}
+
+	err = h.storage.PasswordReset().Create(context.Background(), user.Id, hash, h.cfg.PasswordResetTTL)
+	if err != nil {
+		log.Printf("error whiling create reset token: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling create reset token").Error())
+		return
+	}
+
+	err = h.outbox.Send(context.Background(), outbox.Message{
+		To:      user.Email,
+		Subject: "Password reset",
+		Body:    fmt.Sprintf("Use this token to reset your password: %s", token),
+	})
+
+	if err != nil {
+		log.Printf("error whiling send reset token: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling send reset token").Error())
+		return
+	}
+
+	c.JSON(http.StatusAccepted, nil)
+}
+
+// ResetPassword godoc
+// @ID reset_password
+// @Router /auth/password/reset [POST]
+// @Summary Reset Password
+// @Description Set a new password using a reset token. All refresh tokens of the user are revoked.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param reset body models.ResetPasswordRequest true "ResetPasswordRequestBody"
+// @Success 204
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) ResetPassword(c *gin.Context) {
+	var req models.ResetPasswordRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		log.Printf("error whiling reset password: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(req.Password) < minPasswordLength {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("password must be at least %d characters", minPasswordLength))
+		return
+	}
+
+	passwordHash, err := security.HashPassword(req.Password)
+	if err != nil {
+		log.Printf("error whiling hash password: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling hash password").Error())
+		return
+	}
+
+	userId, err := h.storage.PasswordReset().Consume(context.Background(), security.HashToken(req.Token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusBadRequest, errors.New("invalid or expired reset token").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling consume reset token: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling consume reset token").Error())
+		return
+	}
+
+	_, err = h.storage.User().UpdatePassword(context.Background(), userId, passwordHash)
+	if err != nil {
+		log.Printf("error whiling update password: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling update password").Error())
+		return
+	}
+
+	err = h.storage.RefreshToken().RevokeAllByUser(context.Background(), userId)
+	if err != nil {
+		log.Printf("error whiling revoke refresh tokens: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling revoke refresh tokens").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *HandlerV1) issueTokens(user *models.User) (*models.TokenResponse, error) {
+
+	accessToken, err := security.GenerateJWT(user.Id, user.Roles, h.cfg.AccessTokenTTL, h.cfg.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshHash, err := security.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.storage.RefreshToken().Create(context.Background(), user.Id, refreshHash, h.cfg.RefreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(h.cfg.AccessTokenTTL.Seconds()),
+	}, nil
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"crud/models"
+)
+
+func (h *HandlerV1) checkBatch(mode *string, size int) error {
+
+	if *mode == "" {
+		*mode = models.BatchModeAllOrNothing
+	}
+
+	if *mode != models.BatchModeAllOrNothing && *mode != models.BatchModeBestEffort {
+		return fmt.Errorf("mode must be %s or %s", models.BatchModeAllOrNothing, models.BatchModeBestEffort)
+	}
+
+	if size == 0 {
+		return errors.New("batch is empty")
+	}
+
+	if size > h.cfg.BatchMaxItems {
+		return fmt.Errorf("batch must contain at most %d items", h.cfg.BatchMaxItems)
+	}
+
+	return nil
+}
+
+// validateBatch runs validate for every item. In all_or_nothing mode an
+// invalid item fails the whole batch, in best_effort mode only valid items
+// are returned for insertion together with their original indexes.
+func validateBatch(mode string, size int, validate func(i int) error) (valid []int, results []*models.BatchResult) {
+
+	results = make([]*models.BatchResult, size)
+
+	var failed bool
+	for i := 0; i < size; i++ {
+		results[i] = &models.BatchResult{Index: i}
+
+		err := validate(i)
+		if err != nil {
+			results[i].Error = err.Error()
+			failed = true
+			continue
+		}
+
+		valid = append(valid, i)
+	}
+
+	if failed && mode == models.BatchModeAllOrNothing {
+		for _, result := range results {
+			if result.Error == "" {
+				result.Error = "rolled back"
+			}
+		}
+
+		return nil, results
+	}
+
+	return valid, results
+}
+
+// mergeBatch copies the storage results of the valid items back to their
+// original positions.
+func mergeBatch(results []*models.BatchResult, valid []int, stored []*models.BatchResult) {
+	for i, result := range stored {
+		result.Index = valid[i]
+		results[valid[i]] = result
+	}
+}
+
+func batchResponse(mode string, results []*models.BatchResult) *models.BatchResponse {
+
+	resp := &models.BatchResponse{
+		Mode:    mode,
+		Results: results,
+	}
+
+	for _, result := range results {
+		if result.Error != "" {
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+	}
+
+	if mode == models.BatchModeAllOrNothing && resp.Failed > 0 {
+		resp.Succeeded = 0
+		resp.Failed = int32(len(results))
+	}
+
+	return resp
+}
@@ -5,11 +5,12 @@ import (
 	"errors"
 	"log"
 	"net/http"
-	"strconv"
 
 	"crud/models"
+	"crud/storage"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
 )
 
 // CreateCategory godoc
@@ -20,9 +21,11 @@ import (
 // @Tags Category
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
 // @Param category body models.CreateCategory true "CreateCategoryRequestBody"
 // @Success 201 {object} models.Category "GetCategoryBody"
 // @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Conflict"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) CreateCategory(c *gin.Context) {
 	var category models.CreateCategory
@@ -34,7 +37,23 @@ func (h *HandlerV1) CreateCategory(c *gin.Context) {
 		return
 	}
 
+	err = category.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
 	id, err := h.storage.Category().Create(context.Background(), &category)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("category name already exists").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("parent category not found").Error())
+		return
+	}
+
 	if err != nil {
 		log.Printf("error whiling Create: %v\n", err)
 		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
@@ -99,43 +118,92 @@ func (h *HandlerV1) GetCategoryById(c *gin.Context) {
 // @Response 400 {object} string "Invalid Argument"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) GetCategoryList(c *gin.Context) {
-	var (
-		limit  int
-		offset int
-		err    error
-	)
 
-	limitStr := c.Query("limit")
-	if limitStr != "" {
-		limit, err = strconv.Atoi(limitStr)
-		if err != nil {
-			log.Printf("error whiling limit: %v\n", err)
-			c.JSON(http.StatusBadRequest, err.Error())
-			return
-		}
+	req, err := getCategoryListRequest(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
 	}
 
-	offsetStr := c.Query("offset")
-	if offsetStr != "" {
-		offset, err = strconv.Atoi(offsetStr)
-		if err != nil {
-			log.Printf("error whiling limit: %v\n", err)
-			c.JSON(http.StatusBadRequest, err.Error())
-			return
-		}
+	resp, err := h.storage.Category().GetList(context.Background(), req)
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
 	}
 
-	resp, err := h.storage.Category().GetList(
-		context.Background(),
-		&models.GetListCategoryRequest{
-			Limit:  int32(limit),
-			Offset: int32(offset),
-		},
-	)
+	c.JSON(http.StatusOK, resp)
+}
+
+// getCategoryListRequest parses the query shared by the list and export endpoints.
+func getCategoryListRequest(c *gin.Context) (*models.GetListCategoryRequest, error) {
 
+	limit, offset, err := getPagination(c)
 	if err != nil {
-		log.Printf("error whiling get list: %v", err)
-		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return nil, err
+	}
+
+	return &models.GetListCategoryRequest{
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+// GetCategoryTree godoc
+// @ID get_category_tree
+// @Router /category/tree [GET]
+// @Summary Get Category Tree
+// @Description Get every category nested under its parent, siblings ordered by name.
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.GetCategoryTreeResponse "GetCategoryTreeBody"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCategoryTree(c *gin.Context) {
+
+	resp, err := h.storage.Category().GetTree(context.Background())
+	if err != nil {
+		log.Printf("error whiling get tree: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get tree").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetCategoryDescendants godoc
+// @ID get_category_descendants
+// @Router /category/{id}/descendants [GET]
+// @Summary Get Category Descendants
+// @Description Get the subcategories of the category at every depth, each one after its parent.
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.GetCategoryDescendantsResponse "GetCategoryDescendantsBody"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCategoryDescendants(c *gin.Context) {
+
+	pkey := &models.CategoryPrimarKey{Id: c.Param("id")}
+
+	_, err := h.storage.Category().GetByPKey(context.Background(), pkey)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("category not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	resp, err := h.storage.Category().GetDescendants(context.Background(), pkey)
+	if err != nil {
+		log.Printf("error whiling get descendants: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get descendants").Error())
 		return
 	}
 
@@ -154,6 +222,8 @@ func (h *HandlerV1) GetCategoryList(c *gin.Context) {
 // @Param category body models.UpdateCategory true "CreateCategoryRequestBody"
 // @Success 200 {object} models.Category "GetCategorysBody"
 // @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Conflict"
+// @Response 422 {object} string "Parent Is A Descendant"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) UpdateCategory(c *gin.Context) {
 
@@ -176,12 +246,33 @@ func (h *HandlerV1) UpdateCategory(c *gin.Context) {
 		return
 	}
 
+	err = category.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
 	rowsAffected, err := h.storage.Category().Update(
 		context.Background(),
 		id,
 		&category,
 	)
 
+	if errors.Is(err, storage.ErrCategoryCycle) {
+		c.JSON(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("category name already exists").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("parent category not found").Error())
+		return
+	}
+
 	if err != nil {
 		log.Printf("error whiling update: %v", err)
 		c.JSON(http.StatusInternalServerError, errors.New("error whiling update").Error())
@@ -208,6 +299,72 @@ func (h *HandlerV1) UpdateCategory(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// UpsertCategory godoc
+// @ID upsert_category
+// @Router /category/by-name [PUT]
+// @Summary Upsert Category
+// @Description Create the category or update the one with the same name. Responds 201 when created and 200 when updated.
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param category body models.CreateCategory true "CreateCategoryRequestBody"
+// @Success 200 {object} models.Category "GetCategoryBody"
+// @Success 201 {object} models.Category "GetCategoryBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 422 {object} string "Parent Is A Descendant"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UpsertCategory(c *gin.Context) {
+	var category models.CreateCategory
+
+	err := c.ShouldBindJSON(&category)
+	if err != nil {
+		log.Printf("error whiling upsert: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = category.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, created, err := h.storage.Category().Upsert(context.Background(), &category)
+	if errors.Is(err, storage.ErrCategoryCycle) {
+		c.JSON(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("parent category not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Upsert: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Upsert").Error())
+		return
+	}
+
+	resp, err := h.storage.Category().GetByPKey(
+		context.Background(),
+		&models.CategoryPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, resp)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // DeleteByIdCategory godoc
 // @ID delete_by_id_category
 // @Router /category/{id} [DELETE]
@@ -219,6 +376,7 @@ func (h *HandlerV1) UpdateCategory(c *gin.Context) {
 // @Param id path string true "id"
 // @Success 200 {object} models.Category "GetCategoryBody"
 // @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Has Subcategories"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) DeleteCategory(c *gin.Context) {
 
@@ -236,6 +394,11 @@ func (h *HandlerV1) DeleteCategory(c *gin.Context) {
 		},
 	)
 
+	if errors.Is(err, storage.ErrInUse) {
+		c.JSON(http.StatusConflict, errors.New("category still has subcategories").Error())
+		return
+	}
+
 	if err != nil {
 		log.Printf("error whiling delete: %v", err)
 		c.JSON(http.StatusInternalServerError, errors.New("error whiling delete").Error())
@@ -244,3 +407,110 @@ func (h *HandlerV1) DeleteCategory(c *gin.Context) {
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// CreateCategoryBatch godoc
+// @ID create_category_batch
+// @Router /category/batch [POST]
+// @Summary Create Category Batch
+// @Description Create many Category items in one transaction. all_or_nothing mode (default) rolls back on any failure, best_effort mode reports failures per item.
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param batch body models.CreateCategoryBatch true "CreateCategoryBatchRequestBody"
+// @Success 201 {object} models.BatchResponse "BatchBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 422 {object} models.BatchResponse "BatchBody"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateCategoryBatch(c *gin.Context) {
+	var batch models.CreateCategoryBatch
+
+	err := c.ShouldBindJSON(&batch)
+	if err != nil {
+		log.Printf("error whiling create batch: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.checkBatch(&batch.Mode, len(batch.Items))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	valid, results := validateBatch(batch.Mode, len(batch.Items), func(i int) error {
+		if batch.Items[i] == nil {
+			return errors.New("empty item")
+		}
+		return batch.Items[i].Validate()
+	})
+
+	if len(valid) > 0 {
+		items := make([]*models.CreateCategory, 0, len(valid))
+		for _, i := range valid {
+			items = append(items, batch.Items[i])
+		}
+
+		stored, err := h.storage.Category().CreateMany(context.Background(), items, batch.Mode)
+		if err != nil {
+			log.Printf("error whiling CreateMany: %v\n", err)
+			c.JSON(http.StatusInternalServerError, errors.New("error whiling CreateMany").Error())
+			return
+		}
+
+		mergeBatch(results, valid, stored)
+	}
+
+	resp := batchResponse(batch.Mode, results)
+	if resp.Succeeded == 0 {
+		c.JSON(http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// DeleteCategoryBatch godoc
+// @ID delete_category_batch
+// @Router /category/batch/delete [POST]
+// @Summary Delete Category Batch
+// @Description Delete many Category items by id in one transaction. Malformed, missing and still referenced ids fail the batch in all_or_nothing mode (default).
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param batch body models.DeleteManyRequest true "DeleteManyRequestBody"
+// @Success 200 {object} models.BatchResponse "BatchBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 422 {object} models.BatchResponse "BatchBody"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) DeleteCategoryBatch(c *gin.Context) {
+	var batch models.DeleteManyRequest
+
+	err := c.ShouldBindJSON(&batch)
+	if err != nil {
+		log.Printf("error whiling delete batch: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.checkBatch(&batch.Mode, len(batch.Ids))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := h.storage.Category().DeleteMany(context.Background(), batch.Ids, batch.Mode)
+	if err != nil {
+		log.Printf("error whiling DeleteMany: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling DeleteMany").Error())
+		return
+	}
+
+	resp := batchResponse(batch.Mode, results)
+	if resp.Succeeded == 0 {
+		c.JSON(http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+// CreateCity godoc
+// @ID create_city
+// @Router /city [POST]
+// @Summary Create City
+// @Description Create City
+// @Tags City
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param city body models.CreateCity true "CreateCityRequestBody"
+// @Success 201 {object} models.City "GetCityBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Already Exists"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateCity(c *gin.Context) {
+	var city models.CreateCity
+
+	err := c.ShouldBindJSON(&city)
+	if err != nil {
+		log.Printf("error whiling create: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = city.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.storage.City().Create(context.Background(), &city)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("city already exists").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("country not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Create: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
+		return
+	}
+
+	resp, err := h.storage.City().GetByPKey(
+		context.Background(),
+		&models.CityPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetByIdCity godoc
+// @ID get_by_id_city
+// @Router /city/{id} [GET]
+// @Summary Get By Id City
+// @Description Get By Id City
+// @Tags City
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.City "GetCityBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCityById(c *gin.Context) {
+
+	id := c.Param("id")
+
+	resp, err := h.storage.City().GetByPKey(
+		context.Background(),
+		&models.CityPrimarKey{Id: id},
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("city not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetListCity godoc
+// @ID get_list_city
+// @Router /city [GET]
+// @Summary Get List City
+// @Description Get List City
+// @Tags City
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param country_id query string false "country_id"
+// @Success 200 {object} models.GetListCityResponse "GetCityBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCityList(c *gin.Context) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.storage.City().GetList(context.Background(), &models.GetListCityRequest{
+		Limit:     limit,
+		Offset:    offset,
+		CountryId: c.Query("country_id"),
+	})
+
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateCity godoc
+// @ID update_city
+// @Router /city/{id} [PUT]
+// @Summary Update City
+// @Description Update City
+// @Tags City
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param city body models.UpdateCity true "UpdateCityRequestBody"
+// @Success 200 {object} models.City "GetCityBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "Already Exists"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UpdateCity(c *gin.Context) {
+
+	var (
+		city models.UpdateCity
+	)
+
+	id := c.Param("id")
+
+	err := c.ShouldBindJSON(&city)
+	if err != nil {
+		log.Printf("error whiling update: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = city.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rowsAffected, err := h.storage.City().Update(
+		context.Background(),
+		id,
+		&city,
+	)
+
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("city already exists").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("country not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling update: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling update").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("city not found").Error())
+		return
+	}
+
+	resp, err := h.storage.City().GetByPKey(
+		context.Background(),
+		&models.CityPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteByIdCity godoc
+// @ID delete_by_id_city
+// @Router /city/{id} [DELETE]
+// @Summary Delete By Id City
+// @Description Delete the city. Fails while addresses still reference it.
+// @Tags City
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "In Use"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) DeleteCity(c *gin.Context) {
+
+	id := c.Param("id")
+
+	rowsAffected, err := h.storage.City().Delete(
+		context.Background(),
+		&models.CityPrimarKey{
+			Id: id,
+		},
+	)
+
+	if errors.Is(err, storage.ErrInUse) {
+		c.JSON(http.StatusConflict, errors.New("city still has addresses").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling delete: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling delete").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("city not found").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
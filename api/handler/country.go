@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+// CreateCountry godoc
+// @ID create_country
+// @Router /country [POST]
+// @Summary Create Country
+// @Description Create Country
+// @Tags Country
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param country body models.CreateCountry true "CreateCountryRequestBody"
+// @Success 201 {object} models.Country "GetCountryBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Already Exists"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateCountry(c *gin.Context) {
+	var country models.CreateCountry
+
+	err := c.ShouldBindJSON(&country)
+	if err != nil {
+		log.Printf("error whiling create: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = country.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.storage.Country().Create(context.Background(), &country)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("country already exists").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Create: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
+		return
+	}
+
+	resp, err := h.storage.Country().GetByPKey(
+		context.Background(),
+		&models.CountryPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetByIdCountry godoc
+// @ID get_by_id_country
+// @Router /country/{id} [GET]
+// @Summary Get By Id Country
+// @Description Get By Id Country
+// @Tags Country
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Country "GetCountryBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCountryById(c *gin.Context) {
+
+	id := c.Param("id")
+
+	resp, err := h.storage.Country().GetByPKey(
+		context.Background(),
+		&models.CountryPrimarKey{Id: id},
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("country not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetListCountry godoc
+// @ID get_list_country
+// @Router /country [GET]
+// @Summary Get List Country
+// @Description Get List Country
+// @Tags Country
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.GetListCountryResponse "GetCountryBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCountryList(c *gin.Context) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.storage.Country().GetList(context.Background(), &models.GetListCountryRequest{
+		Limit:  limit,
+		Offset: offset,
+	})
+
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateCountry godoc
+// @ID update_country
+// @Router /country/{id} [PUT]
+// @Summary Update Country
+// @Description Update Country
+// @Tags Country
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param country body models.UpdateCountry true "UpdateCountryRequestBody"
+// @Success 200 {object} models.Country "GetCountryBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "Already Exists"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UpdateCountry(c *gin.Context) {
+
+	var (
+		country models.UpdateCountry
+	)
+
+	id := c.Param("id")
+
+	err := c.ShouldBindJSON(&country)
+	if err != nil {
+		log.Printf("error whiling update: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = country.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rowsAffected, err := h.storage.Country().Update(
+		context.Background(),
+		id,
+		&country,
+	)
+
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("country already exists").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling update: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling update").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("country not found").Error())
+		return
+	}
+
+	resp, err := h.storage.Country().GetByPKey(
+		context.Background(),
+		&models.CountryPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteByIdCountry godoc
+// @ID delete_by_id_country
+// @Router /country/{id} [DELETE]
+// @Summary Delete By Id Country
+// @Description Delete the country. Fails while cities still reference it.
+// @Tags Country
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "In Use"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) DeleteCountry(c *gin.Context) {
+
+	id := c.Param("id")
+
+	rowsAffected, err := h.storage.Country().Delete(
+		context.Background(),
+		&models.CountryPrimarKey{
+			Id: id,
+		},
+	)
+
+	if errors.Is(err, storage.ErrInUse) {
+		c.JSON(http.StatusConflict, errors.New("country still has cities").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling delete: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling delete").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("country not found").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+// CreateCustomer godoc
+// @ID create_customer
+// @Router /customer [POST]
+// @Summary Create Customer
+// @Description Create the customer together with its address
+// @Tags Customer
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param customer body models.CreateCustomer true "CreateCustomerRequestBody"
+// @Success 201 {object} models.Customer "GetCustomerBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Email Already Registered"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateCustomer(c *gin.Context) {
+	var customer models.CreateCustomer
+
+	err := c.ShouldBindJSON(&customer)
+	if err != nil {
+		log.Printf("error whiling create: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = customer.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var id string
+
+	err = h.storage.WithTx(context.Background(), func(tx storage.StorageI) error {
+
+		customer.AddressId, err = tx.Address().Create(context.Background(), &customer.Address)
+		if err != nil {
+			return err
+		}
+
+		id, err = tx.Customer().Create(context.Background(), &customer)
+
+		return err
+	})
+
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("email already registered").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("store or city not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Create: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
+		return
+	}
+
+	resp, err := h.storage.Customer().GetByPKey(
+		context.Background(),
+		&models.CustomerPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetByIdCustomer godoc
+// @ID get_by_id_customer
+// @Router /customer/{id} [GET]
+// @Summary Get By Id Customer
+// @Description Get the customer with its address
+// @Tags Customer
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Customer "GetCustomerBody"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCustomerById(c *gin.Context) {
+
+	id := c.Param("id")
+
+	resp, err := h.storage.Customer().GetByPKey(
+		context.Background(),
+		&models.CustomerPrimarKey{Id: id},
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("customer not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetListCustomer godoc
+// @ID get_list_customer
+// @Router /customer [GET]
+// @Summary Get List Customer
+// @Description Get List Customer
+// @Tags Customer
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param store_id query string false "store_id"
+// @Param city_id query string false "city_id"
+// @Param active query bool false "active"
+// @Success 200 {object} models.GetListCustomerResponse "GetCustomerBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCustomerList(c *gin.Context) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := &models.GetListCustomerRequest{
+		Limit:   limit,
+		Offset:  offset,
+		StoreId: c.Query("store_id"),
+		CityId:  c.Query("city_id"),
+	}
+
+	if value := c.Query("active"); value != "" {
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errors.New("active must be true or false").Error())
+			return
+		}
+		req.Active = &active
+	}
+
+	resp, err := h.storage.Customer().GetList(context.Background(), req)
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateCustomer godoc
+// @ID update_customer
+// @Router /customer/{id} [PUT]
+// @Summary Update Customer
+// @Description Replace the customer and its address. active is kept when it is left out.
+// @Tags Customer
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param customer body models.UpdateCustomer true "UpdateCustomerRequestBody"
+// @Success 200 {object} models.Customer "GetCustomerBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "Email Already Registered"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UpdateCustomer(c *gin.Context) {
+
+	var (
+		customer models.UpdateCustomer
+	)
+
+	id := c.Param("id")
+
+	err := c.ShouldBindJSON(&customer)
+	if err != nil {
+		log.Printf("error whiling update: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = customer.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.storage.WithTx(context.Background(), func(tx storage.StorageI) error {
+
+		current, err := tx.Customer().GetByPKey(
+			context.Background(),
+			&models.CustomerPrimarKey{Id: id},
+		)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Customer().Update(context.Background(), id, &customer)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Address().Update(context.Background(), current.Address.Id, &customer.Address)
+
+		return err
+	})
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("customer not found").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("email already registered").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("store or city not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling update: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling update").Error())
+		return
+	}
+
+	resp, err := h.storage.Customer().GetByPKey(
+		context.Background(),
+		&models.CustomerPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteByIdCustomer godoc
+// @ID delete_by_id_customer
+// @Router /customer/{id} [DELETE]
+// @Summary Delete By Id Customer
+// @Description Delete the customer and its address. Customers with rental history should be deactivated instead.
+// @Tags Customer
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "In Use"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) DeleteCustomer(c *gin.Context) {
+
+	id := c.Param("id")
+
+	rowsAffected, err := h.storage.Customer().Delete(
+		context.Background(),
+		&models.CustomerPrimarKey{
+			Id: id,
+		},
+	)
+
+	if errors.Is(err, storage.ErrInUse) {
+		c.JSON(http.StatusConflict, errors.New("customer has rental history, deactivate it instead").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling delete: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling delete").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("customer not found").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
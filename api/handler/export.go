@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"crud/models"
+	"crud/pkg/export"
+)
+
+var (
+	filmExportColumns     = []string{"film_id", "title", "description", "release_year", "duration", "language_id", "original_language_id", "rating", "rental_duration", "rental_rate", "replacement_cost", "special_features", "created_at", "updated_at"}
+	actorExportColumns    = []string{"actor_id", "first_name", "last_name", "display_name", "biography", "birth_date", "birthplace", "imdb_id", "tmdb_id", "wikidata_id", "photo_url", "created_at", "updated_at"}
+	categoryExportColumns = []string{"category_id", "name", "parent_id", "created_at", "updated_at"}
+)
+
+// ExportFilm godoc
+// @ID export_film
+// @Router /film/export [GET]
+// @Summary Export Film
+// @Description Stream films as a CSV, NDJSON or XLSX download. Accepts the same filters as the list endpoint.
+// @Tags Film
+// @Produce text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default), ndjson or xlsx"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit, all rows when empty"
+// @Param rating query string false "comma separated ratings, e.g. PG,PG-13"
+// @Param min_rental_rate query string false "min_rental_rate"
+// @Param max_rental_rate query string false "max_rental_rate"
+// @Param min_replacement_cost query string false "min_replacement_cost"
+// @Param max_replacement_cost query string false "max_replacement_cost"
+// @Param category_id query string false "category_id"
+// @Param include_descendants query bool false "also match films in the subcategories of category_id"
+// @Success 200 {file} file "Export"
+// @Response 400 {object} string "Invalid Argument"
+func (h *HandlerV1) ExportFilm(c *gin.Context) {
+
+	req, err := getFilmListRequest(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.export(c, "film", filmExportColumns, func(ctx context.Context, writer export.Writer) error {
+		return h.storage.Film().Export(ctx, req, func(film *models.Film) error {
+			return writer.Write(film, []string{
+				film.Id,
+				film.Title,
+				film.Description,
+				film.ReleaseYear,
+				strconv.Itoa(int(film.Duration)),
+				languageId(film.Language),
+				languageId(film.OriginalLanguage),
+				film.Rating,
+				strconv.Itoa(int(film.RentalDuration)),
+				film.RentalRate.StringFixed(2),
+				film.ReplacementCost.StringFixed(2),
+				strings.Join(film.SpecialFeatures, ","),
+				film.CreatedAt,
+				film.UpdatedAt,
+			})
+		})
+	})
+}
+
+// ExportActor godoc
+// @ID export_actor
+// @Router /actor/export [GET]
+// @Summary Export Actor
+// @Description Stream actors as a CSV, NDJSON or XLSX download. Accepts the same filters as the list endpoint.
+// @Tags Actor
+// @Produce text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default), ndjson or xlsx"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit, all rows when empty"
+// @Param birth_year query int false "birth_year"
+// @Success 200 {file} file "Export"
+// @Response 400 {object} string "Invalid Argument"
+func (h *HandlerV1) ExportActor(c *gin.Context) {
+
+	req, err := getActorListRequest(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.export(c, "actor", actorExportColumns, func(ctx context.Context, writer export.Writer) error {
+		return h.storage.Actor().Export(ctx, req, func(actor *models.Actor) error {
+			return writer.Write(actor, []string{
+				actor.Id,
+				actor.First_name,
+				actor.Last_name,
+				actor.DisplayName,
+				actor.Biography,
+				actor.BirthDate,
+				actor.Birthplace,
+				actor.ExternalIds["imdb"],
+				actor.ExternalIds["tmdb"],
+				actor.ExternalIds["wikidata"],
+				actor.PhotoUrl,
+				actor.CreatedAt,
+				actor.UpdatedAt,
+			})
+		})
+	})
+}
+
+// ExportCategory godoc
+// @ID export_category
+// @Router /category/export [GET]
+// @Summary Export Category
+// @Description Stream categories as a CSV, NDJSON or XLSX download. Accepts the same filters as the list endpoint.
+// @Tags Category
+// @Produce text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default), ndjson or xlsx"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit, all rows when empty"
+// @Success 200 {file} file "Export"
+// @Response 400 {object} string "Invalid Argument"
+func (h *HandlerV1) ExportCategory(c *gin.Context) {
+
+	req, err := getCategoryListRequest(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.export(c, "category", categoryExportColumns, func(ctx context.Context, writer export.Writer) error {
+		return h.storage.Category().Export(ctx, req, func(category *models.Category) error {
+			return writer.Write(category, []string{
+				category.Id,
+				category.Name,
+				category.ParentId,
+				category.CreatedAt,
+				category.UpdatedAt,
+			})
+		})
+	})
+}
+
+// export sets the download headers and streams the rows produced by fn.
+// Once the first byte is sent the status can not change any more, so a
+// failure half way only ends the download early.
+func (h *HandlerV1) export(c *gin.Context, entity string, columns []string, fn func(ctx context.Context, writer export.Writer) error) {
+
+	format := c.DefaultQuery("format", export.FormatCSV)
+	if !export.IsFormat(format) {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("unsupported format %q", format))
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", entity, time.Now().Format("20060102-150405"), format)
+
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	writer, err := export.NewWriter(c.Writer, format, entity, columns)
+	if err != nil {
+		log.Printf("error whiling export: %v\n", err)
+		return
+	}
+
+	err = fn(c.Request.Context(), writer)
+	if err != nil {
+		log.Printf("error whiling export: %v\n", err)
+		return
+	}
+
+	err = writer.Close()
+	if err != nil {
+		log.Printf("error whiling export: %v\n", err)
+	}
+}
+
+// languageId flattens an expanded language to the id the import endpoint expects.
+func languageId(language *models.Language) string {
+
+	if language == nil {
+		return ""
+	}
+
+	return language.Id
+}
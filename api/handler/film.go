@@ -3,13 +3,17 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"crud/models"
+	"crud/storage"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 )
 
 // CreateFilm godoc
@@ -20,9 +24,11 @@ import (
 // @Tags Film
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
 // @Param film body models.CreateFilm true "CreateFilmRequestBody"
 // @Success 201 {object} models.Film "GetFilmBody"
 // @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Conflict"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) CreateFilm(c *gin.Context) {
 	var film models.CreateFilm
@@ -34,7 +40,23 @@ func (h *HandlerV1) CreateFilm(c *gin.Context) {
 		return
 	}
 
+	err = film.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
 	id, err := h.storage.Film().Create(context.Background(), &film)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("film with the same title and release year already exists").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("language not found").Error())
+		return
+	}
+
 	if err != nil {
 		log.Printf("error whiling Create: %v\n", err)
 		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
@@ -95,51 +117,86 @@ func (h *HandlerV1) GetFilmById(c *gin.Context) {
 // @Produce json
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
+// @Param rating query string false "comma separated ratings, e.g. PG,PG-13"
+// @Param min_rental_rate query string false "min_rental_rate"
+// @Param max_rental_rate query string false "max_rental_rate"
+// @Param min_replacement_cost query string false "min_replacement_cost"
+// @Param max_replacement_cost query string false "max_replacement_cost"
+// @Param category_id query string false "category_id"
+// @Param include_descendants query bool false "also match films in the subcategories of category_id"
 // @Success 200 {object} models.GetListFilmResponse "GetFilmBody"
 // @Response 400 {object} string "Invalid Argument"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) GetFilmList(c *gin.Context) {
-	var (
-		limit  int
-		offset int
-		err    error
-	)
 
-	limitStr := c.Query("limit")
-	if limitStr != "" {
-		limit, err = strconv.Atoi(limitStr)
+	req, err := getFilmListRequest(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.storage.Film().GetList(context.Background(), req)
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getFilmListRequest parses the query shared by the list and export endpoints.
+func getFilmListRequest(c *gin.Context) (*models.GetListFilmRequest, error) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &models.GetListFilmRequest{
+		Limit:      limit,
+		Offset:     offset,
+		CategoryId: c.Query("category_id"),
+	}
+
+	if value := c.Query("include_descendants"); value != "" {
+		include, err := strconv.ParseBool(value)
 		if err != nil {
-			log.Printf("error whiling limit: %v\n", err)
-			c.JSON(http.StatusBadRequest, err.Error())
-			return
+			return nil, errors.New("include_descendants must be true or false")
 		}
+		req.IncludeDescendants = include
 	}
 
-	offsetStr := c.Query("offset")
-	if offsetStr != "" {
-		offset, err = strconv.Atoi(offsetStr)
-		if err != nil {
-			log.Printf("error whiling limit: %v\n", err)
-			c.JSON(http.StatusBadRequest, err.Error())
-			return
+	if rating := c.Query("rating"); rating != "" {
+		for _, r := range strings.Split(rating, ",") {
+			r = strings.TrimSpace(r)
+			if !models.IsRating(r) {
+				return nil, fmt.Errorf("rating must be one of %v", models.Ratings)
+			}
+			req.Ratings = append(req.Ratings, r)
 		}
 	}
 
-	resp, err := h.storage.Film().GetList(
-		context.Background(),
-		&models.GetListFilmRequest{
-			Limit:  int32(limit),
-			Offset: int32(offset),
-		},
-	)
+	for name, dest := range map[string]**decimal.Decimal{
+		"min_rental_rate":      &req.MinRentalRate,
+		"max_rental_rate":      &req.MaxRentalRate,
+		"min_replacement_cost": &req.MinReplacementCost,
+		"max_replacement_cost": &req.MaxReplacementCost,
+	} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
 
-	if err != nil {
-		log.Printf("error whiling get list: %v", err)
-		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
-		return
+		amount, err := decimal.NewFromString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", name, value)
+		}
+		*dest = &amount
 	}
 
-	c.JSON(http.StatusOK, resp)
+	return req, nil
 }
 
 // UpdateFilm godoc
@@ -154,6 +211,7 @@ func (h *HandlerV1) GetFilmList(c *gin.Context) {
 // @Param film body models.UpdateFilm true "CreateFilmRequestBody"
 // @Success 200 {object} models.Film "GetFilmsBody"
 // @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Conflict"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) UpdateFilm(c *gin.Context) {
 
@@ -176,12 +234,28 @@ func (h *HandlerV1) UpdateFilm(c *gin.Context) {
 		return
 	}
 
+	err = film.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
 	rowsAffected, err := h.storage.Film().Update(
 		context.Background(),
 		id,
 		&film,
 	)
 
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("film with the same title and release year already exists").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("language not found").Error())
+		return
+	}
+
 	if err != nil {
 		log.Printf("error whiling update: %v", err)
 		c.JSON(http.StatusInternalServerError, errors.New("error whiling update").Error())
@@ -208,6 +282,66 @@ func (h *HandlerV1) UpdateFilm(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// UpsertFilm godoc
+// @ID upsert_film
+// @Router /film/by-title-year [PUT]
+// @Summary Upsert Film
+// @Description Create the film or update the one with the same title and release_year. Responds 201 when created and 200 when updated.
+// @Tags Film
+// @Accept json
+// @Produce json
+// @Param film body models.CreateFilm true "CreateFilmRequestBody"
+// @Success 200 {object} models.Film "GetFilmBody"
+// @Success 201 {object} models.Film "GetFilmBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UpsertFilm(c *gin.Context) {
+	var film models.CreateFilm
+
+	err := c.ShouldBindJSON(&film)
+	if err != nil {
+		log.Printf("error whiling upsert: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = film.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, created, err := h.storage.Film().Upsert(context.Background(), &film)
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("language not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Upsert: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Upsert").Error())
+		return
+	}
+
+	resp, err := h.storage.Film().GetByPKey(
+		context.Background(),
+		&models.FilmPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, resp)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // DeleteByIdFilm godoc
 // @ID delete_by_id_film
 // @Router /film/{id} [DELETE]
@@ -219,6 +353,7 @@ func (h *HandlerV1) UpdateFilm(c *gin.Context) {
 // @Param id path string true "id"
 // @Success 200 {object} models.Film "GetFilmBody"
 // @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Has Rental History"
 // @Failure 500 {object} string "Server Error"
 func (h *HandlerV1) DeleteFilm(c *gin.Context) {
 
@@ -229,18 +364,342 @@ func (h *HandlerV1) DeleteFilm(c *gin.Context) {
 		return
 	}
 
-	err := h.storage.Film().Delete(
+	assets, err := h.storage.Film().Delete(
 		context.Background(),
 		&models.FilmPrimarKey{
 			Id: id,
 		},
 	)
 
+	if errors.Is(err, storage.ErrInUse) {
+		c.JSON(http.StatusConflict, errors.New("film has rental history").Error())
+		return
+	}
+
 	if err != nil {
 		log.Printf("error whiling delete: %v", err)
 		c.JSON(http.StatusInternalServerError, errors.New("error whiling delete").Error())
 		return
 	}
 
+	h.deleteAssets(assets)
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// CreateFilmBatch godoc
+// @ID create_film_batch
+// @Router /film/batch [POST]
+// @Summary Create Film Batch
+// @Description Create many Film items in one transaction. all_or_nothing mode (default) rolls back on any failure, best_effort mode reports failures per item.
+// @Tags Film
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param batch body models.CreateFilmBatch true "CreateFilmBatchRequestBody"
+// @Success 201 {object} models.BatchResponse "BatchBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 422 {object} models.BatchResponse "BatchBody"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateFilmBatch(c *gin.Context) {
+	var batch models.CreateFilmBatch
+
+	err := c.ShouldBindJSON(&batch)
+	if err != nil {
+		log.Printf("error whiling create batch: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.checkBatch(&batch.Mode, len(batch.Items))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	valid, results := validateBatch(batch.Mode, len(batch.Items), func(i int) error {
+		if batch.Items[i] == nil {
+			return errors.New("empty item")
+		}
+		return batch.Items[i].Validate()
+	})
+
+	if len(valid) > 0 {
+		items := make([]*models.CreateFilm, 0, len(valid))
+		for _, i := range valid {
+			items = append(items, batch.Items[i])
+		}
+
+		stored, err := h.storage.Film().CreateMany(context.Background(), items, batch.Mode)
+		if err != nil {
+			log.Printf("error whiling CreateMany: %v\n", err)
+			c.JSON(http.StatusInternalServerError, errors.New("error whiling CreateMany").Error())
+			return
+		}
+
+		mergeBatch(results, valid, stored)
+	}
+
+	resp := batchResponse(batch.Mode, results)
+	if resp.Succeeded == 0 {
+		c.JSON(http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// DeleteFilmBatch godoc
+// @ID delete_film_batch
+// @Router /film/batch/delete [POST]
+// @Summary Delete Film Batch
+// @Description Delete many Film items by id in one transaction. Malformed, missing and still referenced ids fail the batch in all_or_nothing mode (default).
+// @Tags Film
+// @Accept json
+// @Produce json
+// @Param batch body models.DeleteManyRequest true "DeleteManyRequestBody"
+// @Success 200 {object} models.BatchResponse "BatchBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 422 {object} models.BatchResponse "BatchBody"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) DeleteFilmBatch(c *gin.Context) {
+	var batch models.DeleteManyRequest
+
+	err := c.ShouldBindJSON(&batch)
+	if err != nil {
+		log.Printf("error whiling delete batch: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.checkBatch(&batch.Mode, len(batch.Ids))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, assets, err := h.storage.Film().DeleteMany(context.Background(), batch.Ids, batch.Mode)
+	if err != nil {
+		log.Printf("error whiling DeleteMany: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling DeleteMany").Error())
+		return
+	}
+
+	h.deleteAssets(assets)
+
+	resp := batchResponse(batch.Mode, results)
+	if resp.Succeeded == 0 {
+		c.JSON(http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetFilmActors godoc
+// @ID get_film_actors
+// @Router /film/{id}/actors [GET]
+// @Summary Get Film Actors
+// @Description Get the cast of a film
+// @Tags Film
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.GetFilmActorsResponse "GetFilmActorsBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetFilmActors(c *gin.Context) {
+
+	id := c.Param("id")
+
+	resp, err := h.storage.Film().GetActors(
+		context.Background(),
+		&models.FilmPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetActors: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetActors").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// AddFilmActor godoc
+// @ID add_film_actor
+// @Router /film/{id}/actors/{actor_id} [PUT]
+// @Summary Add Film Actor
+// @Description Add an actor to the cast of a film
+// @Tags Film
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param actor_id path string true "actor_id"
+// @Success 204
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) AddFilmActor(c *gin.Context) {
+
+	err := h.storage.Film().AddActor(
+		context.Background(),
+		&models.FilmActorPrimarKey{
+			FilmId:  c.Param("id"),
+			ActorId: c.Param("actor_id"),
+		},
+	)
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusNotFound, errors.New("film or actor not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling AddActor: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling AddActor").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// RemoveFilmActor godoc
+// @ID remove_film_actor
+// @Router /film/{id}/actors/{actor_id} [DELETE]
+// @Summary Remove Film Actor
+// @Description Remove an actor from the cast of a film
+// @Tags Film
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param actor_id path string true "actor_id"
+// @Success 204
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) RemoveFilmActor(c *gin.Context) {
+
+	rowsAffected, err := h.storage.Film().RemoveActor(
+		context.Background(),
+		&models.FilmActorPrimarKey{
+			FilmId:  c.Param("id"),
+			ActorId: c.Param("actor_id"),
+		},
+	)
+
+	if err != nil {
+		log.Printf("error whiling RemoveActor: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling RemoveActor").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("actor is not in the cast").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetFilmCategories godoc
+// @ID get_film_categories
+// @Router /film/{id}/categories [GET]
+// @Summary Get Film Categories
+// @Description Get the categories of a film
+// @Tags Film
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.GetFilmCategoriesResponse "GetFilmCategoriesBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetFilmCategories(c *gin.Context) {
+
+	id := c.Param("id")
+
+	resp, err := h.storage.Film().GetCategories(
+		context.Background(),
+		&models.FilmPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetCategories: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetCategories").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// AddFilmCategory godoc
+// @ID add_film_category
+// @Router /film/{id}/categories/{category_id} [PUT]
+// @Summary Add Film Category
+// @Description Put a film into a category
+// @Tags Film
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param category_id path string true "category_id"
+// @Success 204
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) AddFilmCategory(c *gin.Context) {
+
+	err := h.storage.Film().AddCategory(
+		context.Background(),
+		&models.FilmCategoryPrimarKey{
+			FilmId:     c.Param("id"),
+			CategoryId: c.Param("category_id"),
+		},
+	)
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusNotFound, errors.New("film or category not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling AddCategory: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling AddCategory").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// RemoveFilmCategory godoc
+// @ID remove_film_category
+// @Router /film/{id}/categories/{category_id} [DELETE]
+// @Summary Remove Film Category
+// @Description Remove a film from a category
+// @Tags Film
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param category_id path string true "category_id"
+// @Success 204
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) RemoveFilmCategory(c *gin.Context) {
+
+	rowsAffected, err := h.storage.Film().RemoveCategory(
+		context.Background(),
+		&models.FilmCategoryPrimarKey{
+			FilmId:     c.Param("id"),
+			CategoryId: c.Param("category_id"),
+		},
+	)
+
+	if err != nil {
+		log.Printf("error whiling RemoveCategory: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling RemoveCategory").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("film is not in the category").Error())
+		return
+	}
+
 	c.JSON(http.StatusNoContent, nil)
 }
@@ -1,15 +1,66 @@
 package handler
 
 import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"crud/api/http"
+	"crud/config"
+	"crud/pkg/media"
+	"crud/pkg/outbox"
+	"crud/pkg/ratelimit"
+	"crud/pkg/rbac"
 	"crud/storage"
 )
 
 type HandlerV1 struct {
+	cfg     config.Config
 	storage storage.StorageI
+	policy  rbac.Policy
+	outbox  outbox.Sink
+	limiter ratelimit.Store
+	blobs   media.BlobStore
 }
 
-func NewHandlerV1(storage storage.StorageI) *HandlerV1 {
+func NewHandlerV1(cfg config.Config, storage storage.StorageI, policy rbac.Policy, outbox outbox.Sink, limiter ratelimit.Store, blobs media.BlobStore) *HandlerV1 {
 	return &HandlerV1{
+		cfg:     cfg,
 		storage: storage,
+		policy:  policy,
+		outbox:  outbox,
+		limiter: limiter,
+		blobs:   blobs,
 	}
 }
+
+func (h *HandlerV1) abortResponse(c *gin.Context, status http.Status, data interface{}) {
+	c.AbortWithStatusJSON(status.Code, http.Response{
+		Status:      status.Status,
+		Description: status.Description,
+		Data:        data,
+	})
+}
+
+func getPagination(c *gin.Context) (limit int32, offset int32, err error) {
+
+	limitStr := c.Query("limit")
+	if limitStr != "" {
+		value, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return 0, 0, err
+		}
+		limit = int32(value)
+	}
+
+	offsetStr := c.Query("offset")
+	if offsetStr != "" {
+		value, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			return 0, 0, err
+		}
+		offset = int32(value)
+	}
+
+	return limit, offset, nil
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	status "crud/api/http"
+	"crud/models"
+)
+
+const maxIdempotencyKeyLength = 255
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays the stored response when a create request is retried
+// with the same Idempotency-Key header. Reusing a key with a different body
+// is rejected with 422.
+func (h *HandlerV1) Idempotency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			h.abortResponse(c, status.BadRequest, "idempotency key is too long")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			log.Printf("error whiling read body: %v\n", err)
+			h.abortResponse(c, status.BadRequest, "error whiling read body")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		identity := getIdentity(c)
+
+		pkey := &models.IdempotencyKeyPrimarKey{
+			Scope: identity.Kind + ":" + identity.Subject + " " + c.Request.Method + " " + c.Request.URL.Path,
+			Key:   key,
+		}
+
+		sum := sha256.Sum256(body)
+		requestHash := hex.EncodeToString(sum[:])
+
+		record, reserved, err := h.storage.IdempotencyKey().Reserve(context.Background(), pkey, requestHash, h.cfg.IdempotencyKeyTTL)
+		if err != nil {
+			log.Printf("error whiling reserve idempotency key: %v\n", err)
+			h.abortResponse(c, status.InternalServerError, "error whiling reserve idempotency key")
+			return
+		}
+
+		if !reserved {
+			switch {
+			case record.RequestHash != requestHash:
+				h.abortResponse(c, status.UnprocessableEntity, "idempotency key was used with a different request body")
+			case !record.Completed:
+				h.abortResponse(c, status.Conflict, "request with this idempotency key is in progress")
+			default:
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(int(record.StatusCode), "application/json; charset=utf-8", record.ResponseBody)
+				c.Abort()
+			}
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+
+		// Server errors and conflicts may resolve on retry, forget the key
+		// instead of replaying them.
+		if c.Writer.Status() >= 500 || c.Writer.Status() == http.StatusConflict {
+			err = h.storage.IdempotencyKey().Release(context.Background(), pkey)
+		} else {
+			err = h.storage.IdempotencyKey().Complete(context.Background(), pkey, c.Writer.Status(), recorder.body.Bytes())
+		}
+
+		if err != nil {
+			log.Printf("error whiling save idempotency key: %v\n", err)
+		}
+	}
+}
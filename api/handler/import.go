@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"crud/pkg/importer"
+)
+
+// ImportFilm godoc
+// @ID import_film
+// @Router /film/import [POST]
+// @Summary Import Film
+// @Description Stream CSV or NDJSON rows into films, upserting by title and release_year
+// @Tags Film
+// @Accept text/csv,application/x-ndjson
+// @Produce json
+// @Param format query string false "csv or ndjson, detected from Content-Type when empty"
+// @Param dry_run query bool false "validate only"
+// @Param rows body string true "CSV with header row or NDJSON"
+// @Success 200 {object} importer.Report "ImportReportBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) ImportFilm(c *gin.Context) {
+	h.importEntity(c, "film")
+}
+
+// ImportActor godoc
+// @ID import_actor
+// @Router /actor/import [POST]
+// @Summary Import Actor
+// @Description Stream CSV or NDJSON rows into actors, upserting by first_name and last_name
+// @Tags Actor
+// @Accept text/csv,application/x-ndjson
+// @Produce json
+// @Param format query string false "csv or ndjson, detected from Content-Type when empty"
+// @Param dry_run query bool false "validate only"
+// @Param rows body string true "CSV with header row or NDJSON"
+// @Success 200 {object} importer.Report "ImportReportBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) ImportActor(c *gin.Context) {
+	h.importEntity(c, "actor")
+}
+
+// ImportCategory godoc
+// @ID import_category
+// @Router /category/import [POST]
+// @Summary Import Category
+// @Description Stream CSV or NDJSON rows into categories, upserting by name
+// @Tags Category
+// @Accept text/csv,application/x-ndjson
+// @Produce json
+// @Param format query string false "csv or ndjson, detected from Content-Type when empty"
+// @Param dry_run query bool false "validate only"
+// @Param rows body string true "CSV with header row or NDJSON"
+// @Success 200 {object} importer.Report "ImportReportBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) ImportCategory(c *gin.Context) {
+	h.importEntity(c, "category")
+}
+
+func (h *HandlerV1) importEntity(c *gin.Context, entity string) {
+
+	var (
+		format = c.Query("format")
+		dryRun bool
+		err    error
+	)
+
+	if format == "" {
+		format = importer.FormatNDJSON
+		if strings.Contains(c.ContentType(), "csv") {
+			format = importer.FormatCSV
+		}
+	}
+
+	dryRunStr := c.Query("dry_run")
+	if dryRunStr != "" {
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			log.Printf("error whiling dry_run: %v\n", err)
+			c.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	target, err := importer.NewTarget(entity, h.storage)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	decoder, err := importer.NewDecoder(c.Request.Body, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := importer.Run(context.Background(), decoder, target, format, dryRun)
+	if err != nil {
+		log.Printf("error whiling import: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling import").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,358 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+// CreateInventory godoc
+// @ID create_inventory
+// @Router /inventory [POST]
+// @Summary Create Inventory
+// @Description Add a copy of a film to a store
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param inventory body models.CreateInventory true "CreateInventoryRequestBody"
+// @Success 201 {object} models.Inventory "GetInventoryBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateInventory(c *gin.Context) {
+	var inventory models.CreateInventory
+
+	err := c.ShouldBindJSON(&inventory)
+	if err != nil {
+		log.Printf("error whiling create: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = inventory.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.storage.Inventory().Create(context.Background(), &inventory)
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("film or store not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Create: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
+		return
+	}
+
+	resp, err := h.storage.Inventory().GetByPKey(
+		context.Background(),
+		&models.InventoryPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetByIdInventory godoc
+// @ID get_by_id_inventory
+// @Router /inventory/{id} [GET]
+// @Summary Get By Id Inventory
+// @Description Get By Id Inventory
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Inventory "GetInventoryBody"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetInventoryById(c *gin.Context) {
+
+	id := c.Param("id")
+
+	resp, err := h.storage.Inventory().GetByPKey(
+		context.Background(),
+		&models.InventoryPrimarKey{Id: id},
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("inventory not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetListInventory godoc
+// @ID get_list_inventory
+// @Router /inventory [GET]
+// @Summary Get List Inventory
+// @Description Get List Inventory
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param film_id query string false "film_id"
+// @Param store_id query string false "store_id"
+// @Param status query string false "available or rented"
+// @Success 200 {object} models.GetListInventoryResponse "GetInventoryBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetInventoryList(c *gin.Context) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	status := c.Query("status")
+	if status != "" && status != models.InventoryStatusAvailable && status != models.InventoryStatusRented {
+		c.JSON(http.StatusBadRequest, errors.New("status must be available or rented").Error())
+		return
+	}
+
+	resp, err := h.storage.Inventory().GetList(context.Background(), &models.GetListInventoryRequest{
+		Limit:   limit,
+		Offset:  offset,
+		FilmId:  c.Query("film_id"),
+		StoreId: c.Query("store_id"),
+		Status:  status,
+	})
+
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateInventory godoc
+// @ID update_inventory
+// @Router /inventory/{id} [PUT]
+// @Summary Update Inventory
+// @Description Move a copy to another store
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param inventory body models.UpdateInventory true "UpdateInventoryRequestBody"
+// @Success 200 {object} models.Inventory "GetInventoryBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UpdateInventory(c *gin.Context) {
+
+	var (
+		inventory models.UpdateInventory
+	)
+
+	id := c.Param("id")
+
+	err := c.ShouldBindJSON(&inventory)
+	if err != nil {
+		log.Printf("error whiling update: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if inventory.StoreId == "" {
+		c.JSON(http.StatusBadRequest, errors.New("required store_id").Error())
+		return
+	}
+
+	rowsAffected, err := h.storage.Inventory().Update(
+		context.Background(),
+		id,
+		&inventory,
+	)
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("store not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling update: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling update").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("inventory not found").Error())
+		return
+	}
+
+	resp, err := h.storage.Inventory().GetByPKey(
+		context.Background(),
+		&models.InventoryPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteByIdInventory godoc
+// @ID delete_by_id_inventory
+// @Router /inventory/{id} [DELETE]
+// @Summary Delete By Id Inventory
+// @Description Delete By Id Inventory
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "In Use"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) DeleteInventory(c *gin.Context) {
+
+	id := c.Param("id")
+
+	rowsAffected, err := h.storage.Inventory().Delete(
+		context.Background(),
+		&models.InventoryPrimarKey{
+			Id: id,
+		},
+	)
+
+	if errors.Is(err, storage.ErrInUse) {
+		c.JSON(http.StatusConflict, errors.New("inventory item has rental history").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling delete: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling delete").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("inventory not found").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetFilmInventory godoc
+// @ID get_film_inventory
+// @Router /film/{id}/inventory [GET]
+// @Summary Get Film Inventory
+// @Description Count the available and rented copies of a film in every store
+// @Tags Film
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.GetFilmInventoryResponse "GetFilmInventoryBody"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetFilmInventory(c *gin.Context) {
+
+	pkey := &models.FilmPrimarKey{Id: c.Param("id")}
+
+	_, err := h.storage.Film().GetByPKey(context.Background(), pkey)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("film not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	resp, err := h.storage.Inventory().CountByFilm(context.Background(), pkey)
+	if err != nil {
+		log.Printf("error whiling CountByFilm: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling CountByFilm").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetStoreInventory godoc
+// @ID get_store_inventory
+// @Router /store/{id}/inventory [GET]
+// @Summary Get Store Inventory
+// @Description Count the available and rented copies of every film the store holds
+// @Tags Store
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param available query bool false "only films with an available copy"
+// @Success 200 {object} models.GetStoreInventoryResponse "GetStoreInventoryBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetStoreInventory(c *gin.Context) {
+
+	id := c.Param("id")
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.storage.Store().GetByPKey(context.Background(), &models.StorePrimarKey{Id: id})
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("store not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	resp, err := h.storage.Inventory().CountByStore(context.Background(), &models.GetStoreInventoryRequest{
+		Limit:         limit,
+		Offset:        offset,
+		StoreId:       id,
+		AvailableOnly: c.Query("available") == "true",
+	})
+
+	if err != nil {
+		log.Printf("error whiling CountByStore: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling CountByStore").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+// CreateLanguage godoc
+// @ID create_language
+// @Router /language [POST]
+// @Summary Create Language
+// @Description Create Language
+// @Tags Language
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param language body models.CreateLanguage true "CreateLanguageRequestBody"
+// @Success 201 {object} models.Language "GetLanguageBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Already Exists"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateLanguage(c *gin.Context) {
+	var language models.CreateLanguage
+
+	err := c.ShouldBindJSON(&language)
+	if err != nil {
+		log.Printf("error whiling create: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = language.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.storage.Language().Create(context.Background(), &language)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("language already exists").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Create: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
+		return
+	}
+
+	resp, err := h.storage.Language().GetByPKey(
+		context.Background(),
+		&models.LanguagePrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetByIdLanguage godoc
+// @ID get_by_id_language
+// @Router /language/{id} [GET]
+// @Summary Get By Id Language
+// @Description Get By Id Language
+// @Tags Language
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Language "GetLanguageBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetLanguageById(c *gin.Context) {
+
+	id := c.Param("id")
+
+	resp, err := h.storage.Language().GetByPKey(
+		context.Background(),
+		&models.LanguagePrimarKey{Id: id},
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("language not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetListLanguage godoc
+// @ID get_list_language
+// @Router /language [GET]
+// @Summary Get List Language
+// @Description Get List Language
+// @Tags Language
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.GetListLanguageResponse "GetLanguageBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetLanguageList(c *gin.Context) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.storage.Language().GetList(context.Background(), &models.GetListLanguageRequest{
+		Limit:  limit,
+		Offset: offset,
+	})
+
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateLanguage godoc
+// @ID update_language
+// @Router /language/{id} [PUT]
+// @Summary Update Language
+// @Description Update Language
+// @Tags Language
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param language body models.UpdateLanguage true "UpdateLanguageRequestBody"
+// @Success 200 {object} models.Language "GetLanguageBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "Already Exists"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UpdateLanguage(c *gin.Context) {
+
+	var (
+		language models.UpdateLanguage
+	)
+
+	id := c.Param("id")
+
+	err := c.ShouldBindJSON(&language)
+	if err != nil {
+		log.Printf("error whiling update: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = language.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rowsAffected, err := h.storage.Language().Update(
+		context.Background(),
+		id,
+		&language,
+	)
+
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("language already exists").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling update: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling update").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("language not found").Error())
+		return
+	}
+
+	resp, err := h.storage.Language().GetByPKey(
+		context.Background(),
+		&models.LanguagePrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteByIdLanguage godoc
+// @ID delete_by_id_language
+// @Router /language/{id} [DELETE]
+// @Summary Delete By Id Language
+// @Description Delete the language. Films that used it are left without a language.
+// @Tags Language
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) DeleteLanguage(c *gin.Context) {
+
+	id := c.Param("id")
+
+	rowsAffected, err := h.storage.Language().Delete(
+		context.Background(),
+		&models.LanguagePrimarKey{
+			Id: id,
+		},
+	)
+
+	if err != nil {
+		log.Printf("error whiling delete: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling delete").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("language not found").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/pkg/media"
+)
+
+// uploadRule is what an upload endpoint accepts in the file field of the
+// multipart form.
+type uploadRule struct {
+	types     []string
+	maxSize   int64
+	thumbnail bool
+}
+
+func imageUpload(maxSize int64) uploadRule {
+	return uploadRule{
+		types:     []string{media.TypeJPEG, media.TypePNG},
+		maxSize:   maxSize,
+		thumbnail: true,
+	}
+}
+
+func videoUpload(maxSize int64) uploadRule {
+	return uploadRule{
+		types:   []string{media.TypeMP4, media.TypeWebM},
+		maxSize: maxSize,
+	}
+}
+
+// UploadFilmPoster godoc
+// @ID upload_film_poster
+// @Router /film/{id}/poster [POST]
+// @Summary Upload Film Poster
+// @Description Upload a JPEG or PNG poster as the file field of a multipart form. A thumbnail is generated from it and the previous poster is deleted.
+// @Tags Film
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "id"
+// @Param file formData file true "poster"
+// @Success 200 {object} models.Film "GetFilmBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Response 413 {object} string "File Too Large"
+// @Response 415 {object} string "Unsupported Media Type"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UploadFilmPoster(c *gin.Context) {
+	h.uploadFilmMedia(c, "poster", imageUpload(h.cfg.MaxPosterSize), h.storage.Film().SetPoster)
+}
+
+// UploadFilmTrailer godoc
+// @ID upload_film_trailer
+// @Router /film/{id}/trailer [POST]
+// @Summary Upload Film Trailer
+// @Description Upload an MP4 or WebM trailer as the file field of a multipart form. The previous trailer is deleted.
+// @Tags Film
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "id"
+// @Param file formData file true "trailer"
+// @Success 200 {object} models.Film "GetFilmBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Response 413 {object} string "File Too Large"
+// @Response 415 {object} string "Unsupported Media Type"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UploadFilmTrailer(c *gin.Context) {
+	h.uploadFilmMedia(c, "trailer", videoUpload(h.cfg.MaxTrailerSize), h.storage.Film().SetTrailer)
+}
+
+// UploadActorPhoto godoc
+// @ID upload_actor_photo
+// @Router /actor/{id}/photo [POST]
+// @Summary Upload Actor Photo
+// @Description Upload a JPEG or PNG photo as the file field of a multipart form. A thumbnail is generated from it and the previous photo is deleted.
+// @Tags Actor
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "id"
+// @Param file formData file true "photo"
+// @Success 200 {object} models.Actor "GetActorBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Response 413 {object} string "File Too Large"
+// @Response 415 {object} string "Unsupported Media Type"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UploadActorPhoto(c *gin.Context) {
+
+	id := c.Param("id")
+
+	ok := h.replaceMedia(c, "actor", "photo", imageUpload(h.cfg.MaxPhotoSize), h.storage.Actor().SetPhoto)
+	if !ok {
+		return
+	}
+
+	resp, err := h.storage.Actor().GetByPKey(
+		context.Background(),
+		&models.ActorPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *HandlerV1) uploadFilmMedia(c *gin.Context, kind string, rule uploadRule, set mediaSetter) {
+
+	id := c.Param("id")
+
+	ok := h.replaceMedia(c, "film", kind, rule, set)
+	if !ok {
+		return
+	}
+
+	resp, err := h.storage.Film().GetByPKey(
+		context.Background(),
+		&models.FilmPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// mediaSetter points the entity with the id at the stored upload and returns
+// the key of the one it replaced.
+type mediaSetter func(ctx context.Context, id string, asset *models.MediaAsset) (string, error)
+
+// replaceMedia stores the upload as the kind of media of the entity of the
+// path, e.g. the poster of a film, and deletes the one it replaced. It
+// responds itself unless it succeeds.
+func (h *HandlerV1) replaceMedia(c *gin.Context, entity string, kind string, rule uploadRule, set mediaSetter) bool {
+
+	id := c.Param("id")
+
+	asset, ok := h.receiveUpload(c, fmt.Sprintf("%s/%s/%s", entity, id, kind), rule)
+	if !ok {
+		return false
+	}
+
+	previous, err := set(context.Background(), id, asset)
+	if err != nil {
+		h.deleteMedia(asset.Key, rule.thumbnail)
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, fmt.Errorf("%s not found", entity).Error())
+		return false
+	}
+
+	if err != nil {
+		log.Printf("error whiling set %s: %v\n", kind, err)
+		c.JSON(http.StatusInternalServerError, fmt.Errorf("error whiling set %s", kind).Error())
+		return false
+	}
+
+	h.deleteMedia(previous, rule.thumbnail)
+
+	return true
+}
+
+// receiveUpload validates the file field against the rule and stores it, and
+// its thumbnail for images, under a new key below prefix. It responds itself
+// when the upload is rejected.
+func (h *HandlerV1) receiveUpload(c *gin.Context, prefix string, rule uploadRule) (*models.MediaAsset, bool) {
+
+	// Leave room for the rest of the multipart form.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, rule.maxSize+1<<20)
+
+	tooLarge := fmt.Errorf("file must not be larger than %d MB", rule.maxSize>>20).Error()
+
+	header, err := c.FormFile("file")
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, tooLarge)
+		return nil, false
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errors.New("required file").Error())
+		return nil, false
+	}
+
+	if header.Size > rule.maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, tooLarge)
+		return nil, false
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		log.Printf("error whiling open upload: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling open upload").Error())
+		return nil, false
+	}
+	defer file.Close()
+
+	contentType, err := media.Sniff(file, rule.types)
+	if errors.Is(err, media.ErrUnsupportedType) {
+		c.JSON(http.StatusUnsupportedMediaType, fmt.Errorf("file must be one of %v", rule.types).Error())
+		return nil, false
+	}
+
+	if err != nil {
+		log.Printf("error whiling sniff upload: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling read upload").Error())
+		return nil, false
+	}
+
+	key := prefix + "/" + uuid.New().String() + media.Extension(contentType)
+
+	asset := &models.MediaAsset{
+		Key: key,
+		Url: h.blobs.URL(key),
+	}
+
+	if rule.thumbnail {
+
+		thumbnail, err := media.Thumbnail(file, h.cfg.ThumbnailWidth)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, fmt.Errorf("file is not a valid image: %v", err).Error())
+			return nil, false
+		}
+
+		_, err = file.Seek(0, io.SeekStart)
+		if err != nil {
+			log.Printf("error whiling rewind upload: %v\n", err)
+			c.JSON(http.StatusInternalServerError, errors.New("error whiling read upload").Error())
+			return nil, false
+		}
+
+		thumbnailKey := media.ThumbnailKey(key)
+
+		err = h.blobs.Put(context.Background(), thumbnailKey, bytes.NewReader(thumbnail), int64(len(thumbnail)), media.TypeJPEG)
+		if err != nil {
+			log.Printf("error whiling put thumbnail: %v\n", err)
+			c.JSON(http.StatusInternalServerError, errors.New("error whiling store upload").Error())
+			return nil, false
+		}
+
+		asset.ThumbnailUrl = h.blobs.URL(thumbnailKey)
+	}
+
+	err = h.blobs.Put(context.Background(), key, file, header.Size, contentType)
+	if err != nil {
+		h.deleteMedia(key, rule.thumbnail)
+		log.Printf("error whiling put upload: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling store upload").Error())
+		return nil, false
+	}
+
+	return asset, true
+}
+
+// deleteMedia removes a stored upload and its thumbnail. Failures only leave
+// an orphaned file behind, so they are logged instead of failing the request.
+func (h *HandlerV1) deleteMedia(key string, thumbnail bool) {
+
+	if key == "" {
+		return
+	}
+
+	keys := []string{key}
+	if thumbnail {
+		keys = append(keys, media.ThumbnailKey(key))
+	}
+
+	for _, k := range keys {
+		err := h.blobs.Delete(context.Background(), k)
+		if err != nil {
+			log.Printf("error whiling delete %s: %v\n", k, err)
+		}
+	}
+}
+
+// deleteAssets removes the uploads of a deleted entity.
+func (h *HandlerV1) deleteAssets(assets []*models.MediaAsset) {
+	for _, asset := range assets {
+		h.deleteMedia(asset.Key, asset.ThumbnailUrl != "")
+	}
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	status "crud/api/http"
+	"crud/models"
+	"crud/pkg/rbac"
+	"crud/pkg/security"
+)
+
+const (
+	identityKey = "identity"
+	staffKey    = "staff"
+)
+
+// Authenticate resolves the caller identity from the X-API-Key or the
+// Authorization header. Requests without credentials continue as the
+// anonymous role.
+func (h *HandlerV1) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		identity := models.Identity{
+			Kind:  "anonymous",
+			Roles: []string{rbac.RoleAnonymous},
+		}
+
+		key := c.GetHeader("X-API-Key")
+		header := c.GetHeader("Authorization")
+
+		if key != "" {
+			apiKey, err := h.storage.ApiKey().GetActiveByHash(context.Background(), security.HashToken(key))
+			if err != nil {
+				log.Printf("error whiling get api key: %v\n", err)
+				h.abortResponse(c, status.Unauthorized, "invalid api key")
+				return
+			}
+
+			err = h.storage.ApiKey().TouchLastUsed(context.Background(), &models.ApiKeyPrimarKey{Id: apiKey.Id})
+			if err != nil {
+				log.Printf("error whiling touch api key: %v\n", err)
+			}
+
+			identity = models.Identity{
+				Subject:     apiKey.Id,
+				Kind:        "api_key",
+				Permissions: apiKey.Scopes,
+			}
+		} else if header != "" {
+			tokenStr := strings.TrimPrefix(header, "Bearer ")
+
+			claims, err := security.ParseJWT(tokenStr, h.cfg.SecretKey)
+			if err != nil {
+				log.Printf("error whiling parse token: %v\n", err)
+				h.abortResponse(c, status.Unauthorized, "invalid token")
+				return
+			}
+
+			identity = models.Identity{
+				Subject: claims.Subject,
+				Kind:    "user",
+				Roles:   claims.Roles,
+			}
+		}
+
+		c.Set(identityKey, identity)
+		c.Next()
+	}
+}
+
+// Authorize checks the "<entity>:<action>" permission where the action is
+// derived from the request method.
+func (h *HandlerV1) Authorize(entity string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var action string
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead:
+			action = rbac.ActionRead
+		case http.MethodDelete:
+			action = rbac.ActionDelete
+		default:
+			action = rbac.ActionWrite
+		}
+
+		h.authorize(c, rbac.Permission(entity, action))
+	}
+}
+
+// Require checks a fixed permission regardless of the request method.
+func (h *HandlerV1) Require(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.authorize(c, permission)
+	}
+}
+
+func (h *HandlerV1) authorize(c *gin.Context, permission string) {
+
+	identity := getIdentity(c)
+
+	if !h.policy.Allowed(identity.Roles, identity.Permissions, permission) {
+		h.abortResponse(c, status.Forbidden, "missing permission "+permission)
+		return
+	}
+
+	c.Next()
+}
+
+// RateLimit applies the token bucket configured for the route group. The
+// bucket is keyed by API key, user or client IP in that order, so it has to
+// run after Authenticate.
+func (h *HandlerV1) RateLimit(group string) gin.HandlerFunc {
+
+	limit, ok := h.cfg.RateLimits[group]
+	if !ok {
+		limit = h.cfg.RateLimits["default"]
+	}
+
+	return func(c *gin.Context) {
+
+		if limit.Rate <= 0 || h.limiter == nil {
+			c.Next()
+			return
+		}
+
+		identity := getIdentity(c)
+
+		key := group + ":ip:" + c.ClientIP()
+		if identity.Subject != "" {
+			key = group + ":" + identity.Kind + ":" + identity.Subject
+		}
+
+		result, err := h.limiter.Take(context.Background(), key, limit)
+		if err != nil {
+			log.Printf("error whiling rate limit: %v\n", err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(int(math.Ceil(result.Reset.Seconds()))))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+			h.abortResponse(c, status.TooManyRequests, "rate limit exceeded")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireStaff resolves the active staff member linked to the authenticated
+// user so that the write can be attributed to them. API keys and users
+// without a staff record are rejected.
+func (h *HandlerV1) RequireStaff() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		identity := getIdentity(c)
+
+		if identity.Kind != "user" {
+			h.abortResponse(c, status.Forbidden, "staff account required")
+			return
+		}
+
+		staff, err := h.storage.Staff().GetByUserId(context.Background(), identity.Subject)
+		if errors.Is(err, pgx.ErrNoRows) {
+			h.abortResponse(c, status.Forbidden, "staff account required")
+			return
+		}
+
+		if err != nil {
+			log.Printf("error whiling get staff: %v\n", err)
+			h.abortResponse(c, status.InternalServerError, "error whiling get staff")
+			return
+		}
+
+		if !staff.Active {
+			h.abortResponse(c, status.Forbidden, "staff account is inactive")
+			return
+		}
+
+		c.Set(staffKey, staff)
+		c.Next()
+	}
+}
+
+func getStaff(c *gin.Context) *models.Staff {
+	return c.MustGet(staffKey).(*models.Staff)
+}
+
+func getIdentity(c *gin.Context) models.Identity {
+
+	value, ok := c.Get(identityKey)
+	if !ok {
+		return models.Identity{Roles: []string{rbac.RoleAnonymous}}
+	}
+
+	return value.(models.Identity)
+}
@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+// CreatePayment godoc
+// @ID create_payment
+// @Router /payment [POST]
+// @Summary Create Payment
+// @Description Pay for a rental. The outstanding amount of the rental is paid when amount is left out. The payment is recorded against the staff member of the authenticated user.
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param payment body models.CreatePayment true "CreatePaymentRequestBody"
+// @Success 201 {object} models.Payment "GetPaymentBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 403 {object} string "Staff Account Required"
+// @Response 422 {object} string "Amount Exceeds Due"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreatePayment(c *gin.Context) {
+	var payment models.CreatePayment
+
+	err := c.ShouldBindJSON(&payment)
+	if err != nil {
+		log.Printf("error whiling create: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = payment.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	payment.StaffId = getStaff(c).Id
+
+	id, err := h.storage.Payment().Create(context.Background(), &payment)
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("rental not found").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrAmountExceedsDue) {
+		c.JSON(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Create: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
+		return
+	}
+
+	h.respondPayment(c, http.StatusCreated, id)
+}
+
+// RefundPayment godoc
+// @ID refund_payment
+// @Router /payment/{id}/refund [POST]
+// @Summary Refund Payment
+// @Description Refund a payment in full or in part. The refundable rest of the payment is refunded when amount is left out. The refund is recorded against the staff member of the authenticated user.
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param id path string true "id"
+// @Param refund body models.RefundPayment true "RefundPaymentRequestBody"
+// @Success 201 {object} models.Payment "GetPaymentBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 403 {object} string "Staff Account Required"
+// @Response 404 {object} string "Not Found"
+// @Response 422 {object} string "Not Refundable"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) RefundPayment(c *gin.Context) {
+	var refund models.RefundPayment
+
+	err := c.ShouldBindJSON(&refund)
+	if err != nil {
+		log.Printf("error whiling refund: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = refund.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	refund.StaffId = getStaff(c).Id
+
+	id, err := h.storage.Payment().Refund(
+		context.Background(),
+		&models.PaymentPrimarKey{Id: c.Param("id")},
+		&refund,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("payment not found").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrNotRefundable) || errors.Is(err, storage.ErrAmountExceedsRefundable) {
+		c.JSON(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Refund: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Refund").Error())
+		return
+	}
+
+	h.respondPayment(c, http.StatusCreated, id)
+}
+
+// GetByIdPayment godoc
+// @ID get_by_id_payment
+// @Router /payment/{id} [GET]
+// @Summary Get By Id Payment
+// @Description Get By Id Payment
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Payment "GetPaymentBody"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetPaymentById(c *gin.Context) {
+
+	resp, err := h.storage.Payment().GetByPKey(
+		context.Background(),
+		&models.PaymentPrimarKey{Id: c.Param("id")},
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("payment not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetListPayment godoc
+// @ID get_list_payment
+// @Router /payment [GET]
+// @Summary Get List Payment
+// @Description Get List Payment
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param customer_id query string false "customer_id"
+// @Param rental_id query string false "rental_id"
+// @Param staff_id query string false "staff_id"
+// @Success 200 {object} models.GetListPaymentResponse "GetPaymentBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetPaymentList(c *gin.Context) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.storage.Payment().GetList(context.Background(), &models.GetListPaymentRequest{
+		Limit:      limit,
+		Offset:     offset,
+		CustomerId: c.Query("customer_id"),
+		RentalId:   c.Query("rental_id"),
+		StaffId:    c.Query("staff_id"),
+	})
+
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetCustomerBalance godoc
+// @ID get_customer_balance
+// @Router /customer/{id}/balance [GET]
+// @Summary Get Customer Balance
+// @Description Fees charged on the rentals of the customer minus payments, refunds count as unpaid again
+// @Tags Customer
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.CustomerBalance "GetCustomerBalanceBody"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCustomerBalance(c *gin.Context) {
+
+	pkey := &models.CustomerPrimarKey{Id: c.Param("id")}
+
+	_, err := h.storage.Customer().GetByPKey(context.Background(), pkey)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("customer not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	resp, err := h.storage.Payment().GetBalance(context.Background(), pkey)
+	if err != nil {
+		log.Printf("error whiling GetBalance: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetBalance").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *HandlerV1) respondPayment(c *gin.Context, status int, id string) {
+
+	resp, err := h.storage.Payment().GetByPKey(
+		context.Background(),
+		&models.PaymentPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(status, resp)
+}
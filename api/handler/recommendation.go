@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+)
+
+// GetSimilarFilms godoc
+// @ID get_similar_films
+// @Router /film/{id}/similar [GET]
+// @Summary Get Similar Films
+// @Description Films that share actors or categories with the film or were rented by the same customers in the last 12 months, best match first. Read from a periodically refreshed snapshot, see refreshed_at.
+// @Tags Film
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.GetSimilarFilmsResponse "GetSimilarFilmsBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetSimilarFilms(c *gin.Context) {
+
+	id := c.Param("id")
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.storage.Film().GetByPKey(context.Background(), &models.FilmPrimarKey{Id: id})
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("film not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	resp, err := h.storage.Recommendation().Similar(context.Background(), &models.GetSimilarFilmsRequest{
+		FilmId:  id,
+		Limit:   limit,
+		Offset:  offset,
+		Weights: h.cfg.SimilarityWeights,
+	})
+
+	if err != nil {
+		log.Printf("error whiling Similar: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Similar").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetCustomerRecommendations godoc
+// @ID get_customer_recommendations
+// @Router /customer/{id}/recommendations [GET]
+// @Summary Get Customer Recommendations
+// @Description Films similar to the ones the customer rented before that they have not rented yet. Empty for customers without rentals.
+// @Tags Customer
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.GetRecommendationsResponse "GetRecommendationsBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCustomerRecommendations(c *gin.Context) {
+
+	id := c.Param("id")
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.storage.Customer().GetByPKey(context.Background(), &models.CustomerPrimarKey{Id: id})
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("customer not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	resp, err := h.storage.Recommendation().ForCustomer(context.Background(), &models.GetRecommendationsRequest{
+		CustomerId: id,
+		Limit:      limit,
+		Offset:     offset,
+		Weights:    h.cfg.SimilarityWeights,
+	})
+
+	if err != nil {
+		log.Printf("error whiling ForCustomer: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling ForCustomer").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
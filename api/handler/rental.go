@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+// CreateRental godoc
+// @ID create_rental
+// @Router /rental [POST]
+// @Summary Checkout Rental
+// @Description Rent an available inventory item to an active customer. The due date follows the rental_duration of the film. The rental is recorded against the staff member of the authenticated user.
+// @Tags Rental
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param rental body models.CreateRental true "CreateRentalRequestBody"
+// @Success 201 {object} models.Rental "GetRentalBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 403 {object} string "Staff Account Required"
+// @Response 409 {object} string "Inventory Unavailable or Customer Inactive"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateRental(c *gin.Context) {
+	var rental models.CreateRental
+
+	err := c.ShouldBindJSON(&rental)
+	if err != nil {
+		log.Printf("error whiling checkout: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = rental.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rental.StaffId = getStaff(c).Id
+
+	id, err := h.storage.Rental().Checkout(context.Background(), &rental)
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("inventory item or customer not found").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrInventoryUnavailable) || errors.Is(err, storage.ErrCustomerInactive) {
+		c.JSON(http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Checkout: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Checkout").Error())
+		return
+	}
+
+	resp, err := h.storage.Rental().GetByPKey(
+		context.Background(),
+		&models.RentalPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ReturnRental godoc
+// @ID return_rental
+// @Router /rental/{id}/return [POST]
+// @Summary Return Rental
+// @Description Close an open rental, charge the late fee when it is overdue and make the copy available again. The return is recorded against the staff member of the authenticated user.
+// @Tags Rental
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Rental "GetRentalBody"
+// @Response 403 {object} string "Staff Account Required"
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "Already Returned"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) ReturnRental(c *gin.Context) {
+
+	id := c.Param("id")
+
+	err := h.storage.Rental().Return(
+		context.Background(),
+		&models.RentalPrimarKey{Id: id},
+		getStaff(c).Id,
+		h.cfg.LateFees,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("rental not found").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrAlreadyReturned) {
+		c.JSON(http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Return: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Return").Error())
+		return
+	}
+
+	resp, err := h.storage.Rental().GetByPKey(
+		context.Background(),
+		&models.RentalPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetByIdRental godoc
+// @ID get_by_id_rental
+// @Router /rental/{id} [GET]
+// @Summary Get By Id Rental
+// @Description Get By Id Rental
+// @Tags Rental
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Rental "GetRentalBody"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetRentalById(c *gin.Context) {
+
+	id := c.Param("id")
+
+	resp, err := h.storage.Rental().GetByPKey(
+		context.Background(),
+		&models.RentalPrimarKey{Id: id},
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("rental not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetListRental godoc
+// @ID get_list_rental
+// @Router /rental [GET]
+// @Summary Get List Rental
+// @Description Get List Rental
+// @Tags Rental
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param customer_id query string false "customer_id"
+// @Param staff_id query string false "staff_id"
+// @Param status query string false "open, overdue or returned"
+// @Success 200 {object} models.GetListRentalResponse "GetRentalBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetRentalList(c *gin.Context) {
+
+	req, err := getRentalListRequest(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req.CustomerId = c.Query("customer_id")
+	req.StaffId = c.Query("staff_id")
+
+	resp, err := h.storage.Rental().GetList(context.Background(), req)
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetCustomerRentals godoc
+// @ID get_customer_rentals
+// @Router /customer/{id}/rentals [GET]
+// @Summary Get Customer Rentals
+// @Description Rental history of a customer, newest first
+// @Tags Customer
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param status query string false "open, overdue or returned"
+// @Success 200 {object} models.GetListRentalResponse "GetRentalBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCustomerRentals(c *gin.Context) {
+
+	id := c.Param("id")
+
+	req, err := getRentalListRequest(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.storage.Customer().GetByPKey(context.Background(), &models.CustomerPrimarKey{Id: id})
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("customer not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	req.CustomerId = id
+
+	resp, err := h.storage.Rental().GetList(context.Background(), req)
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getRentalListRequest parses the pagination and status filter shared by the
+// rental list endpoints.
+func getRentalListRequest(c *gin.Context) (*models.GetListRentalRequest, error) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		return nil, err
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", models.RentalStatusOpen, models.RentalStatusOverdue, models.RentalStatusReturned:
+	default:
+		return nil, errors.New("status must be open, overdue or returned")
+	}
+
+	return &models.GetListRentalRequest{
+		Limit:  limit,
+		Offset: offset,
+		Status: status,
+	}, nil
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"crud/models"
+	"crud/pkg/export"
+	"crud/pkg/reports"
+)
+
+// GetTopFilmsReport godoc
+// @ID get_top_films_report
+// @Router /reports/top-films [GET]
+// @Summary Top Films Report
+// @Description Films ranked by the rentals made between from and to, with what was paid for them. Dates are inclusive.
+// @Tags Report
+// @Produce json,text/csv
+// @Param from query string false "from, YYYY-MM-DD"
+// @Param to query string false "to, YYYY-MM-DD"
+// @Param store_id query string false "store_id"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit, 10 when empty"
+// @Param format query string false "json (default), csv, ndjson or xlsx"
+// @Success 200 {object} models.GetTopFilmsResponse "GetTopFilmsBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetTopFilmsReport(c *gin.Context) {
+
+	format, err := reports.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	period, err := reports.ParsePeriod(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.storage.Report().TopFilms(context.Background(), &models.GetTopFilmsRequest{
+		ReportPeriod: period,
+		Limit:        limit,
+		Offset:       offset,
+		StoreId:      c.Query("store_id"),
+	})
+
+	if err != nil {
+		log.Printf("error whiling TopFilms: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling TopFilms").Error())
+		return
+	}
+
+	h.report(c, format, reports.TopFilms(resp), resp)
+}
+
+// GetCategoryRevenueReport godoc
+// @ID get_category_revenue_report
+// @Router /reports/revenue-by-category [GET]
+// @Summary Revenue By Category Report
+// @Description Rentals and revenue per category and its share of the total. Read from a periodically refreshed snapshot, see refreshed_at. A film in several categories counts for each of them.
+// @Tags Report
+// @Produce json,text/csv
+// @Param format query string false "json (default), csv, ndjson or xlsx"
+// @Success 200 {object} models.GetCategoryRevenueResponse "GetCategoryRevenueBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetCategoryRevenueReport(c *gin.Context) {
+
+	format, err := reports.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.storage.Report().CategoryRevenue(context.Background())
+	if err != nil {
+		log.Printf("error whiling CategoryRevenue: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling CategoryRevenue").Error())
+		return
+	}
+
+	h.report(c, format, reports.CategoryRevenue(resp), resp)
+}
+
+// GetActorPopularityReport godoc
+// @ID get_actor_popularity_report
+// @Router /reports/actor-popularity [GET]
+// @Summary Actor Popularity Report
+// @Description Actors ranked by the rentals of their films. Read from a periodically refreshed snapshot, see refreshed_at.
+// @Tags Report
+// @Produce json,text/csv
+// @Param offset query string false "offset"
+// @Param limit query string false "limit, 10 when empty"
+// @Param format query string false "json (default), csv, ndjson or xlsx"
+// @Success 200 {object} models.GetActorPopularityResponse "GetActorPopularityBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetActorPopularityReport(c *gin.Context) {
+
+	format, err := reports.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.storage.Report().ActorPopularity(context.Background(), &models.GetActorPopularityRequest{
+		Limit:  limit,
+		Offset: offset,
+	})
+
+	if err != nil {
+		log.Printf("error whiling ActorPopularity: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling ActorPopularity").Error())
+		return
+	}
+
+	h.report(c, format, reports.ActorPopularity(resp), resp)
+}
+
+// GetStoreRevenueReport godoc
+// @ID get_store_revenue_report
+// @Router /reports/store-revenue [GET]
+// @Summary Store Revenue Report
+// @Description Payments and refunds taken between from and to per store, or per staff member with group_by=staff. Dates are inclusive.
+// @Tags Report
+// @Produce json,text/csv
+// @Param from query string false "from, YYYY-MM-DD"
+// @Param to query string false "to, YYYY-MM-DD"
+// @Param group_by query string false "store (default) or staff"
+// @Param format query string false "json (default), csv, ndjson or xlsx"
+// @Success 200 {object} models.GetStoreRevenueResponse "GetStoreRevenueBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetStoreRevenueReport(c *gin.Context) {
+
+	format, err := reports.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	period, err := reports.ParsePeriod(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	groupBy := c.DefaultQuery("group_by", models.ReportGroupByStore)
+	if groupBy != models.ReportGroupByStore && groupBy != models.ReportGroupByStaff {
+		c.JSON(http.StatusBadRequest, errors.New("group_by must be store or staff").Error())
+		return
+	}
+
+	resp, err := h.storage.Report().StoreRevenue(context.Background(), &models.GetStoreRevenueRequest{
+		ReportPeriod: period,
+		GroupBy:      groupBy,
+	})
+
+	if err != nil {
+		log.Printf("error whiling StoreRevenue: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling StoreRevenue").Error())
+		return
+	}
+
+	h.report(c, format, reports.StoreRevenue(resp), resp)
+}
+
+// report responds with resp as JSON or streams the rows of table as a
+// download in one of the export formats.
+func (h *HandlerV1) report(c *gin.Context, format string, table *reports.Table, resp interface{}) {
+
+	if format == reports.FormatJSON {
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	h.export(c, table.Name, table.Columns, func(ctx context.Context, writer export.Writer) error {
+		return table.WriteRows(writer)
+	})
+}
@@ -0,0 +1,338 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+const reviewModeratePermission = "review:moderate"
+
+// CreateFilmReview godoc
+// @ID create_film_review
+// @Router /film/{id}/reviews [POST]
+// @Summary Create Film Review
+// @Description Rate and review a film as the authenticated user, once per film. The review is pending until a moderator approves it.
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param id path string true "id"
+// @Param review body models.CreateReview true "CreateReviewRequestBody"
+// @Success 201 {object} models.Review "GetReviewBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 403 {object} string "User Account Required"
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "Already Reviewed"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateFilmReview(c *gin.Context) {
+	var review models.CreateReview
+
+	identity := getIdentity(c)
+	if identity.Kind != "user" {
+		c.JSON(http.StatusForbidden, errors.New("user account required").Error())
+		return
+	}
+
+	err := c.ShouldBindJSON(&review)
+	if err != nil {
+		log.Printf("error whiling create: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = review.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	review.FilmId = c.Param("id")
+	review.UserId = identity.Subject
+
+	id, err := h.storage.Review().Create(context.Background(), &review)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("film already reviewed").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusNotFound, errors.New("film not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Create: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
+		return
+	}
+
+	resp, err := h.storage.Review().GetByPKey(
+		context.Background(),
+		&models.ReviewPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetFilmReviews godoc
+// @ID get_film_reviews
+// @Router /film/{id}/reviews [GET]
+// @Summary Get Film Reviews
+// @Description Approved reviews of a film. Moderators can list pending and rejected reviews with status.
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param sort query string false "newest (default) or helpful"
+// @Param status query string false "approved (default), pending or rejected"
+// @Success 200 {object} models.GetListReviewResponse "GetReviewBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 403 {object} string "Forbidden"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetFilmReviews(c *gin.Context) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sort := c.DefaultQuery("sort", models.ReviewSortNewest)
+	if sort != models.ReviewSortNewest && sort != models.ReviewSortHelpful {
+		c.JSON(http.StatusBadRequest, errors.New("sort must be newest or helpful").Error())
+		return
+	}
+
+	status := c.DefaultQuery("status", models.ReviewStatusApproved)
+	if !models.IsReviewStatus(status) {
+		c.JSON(http.StatusBadRequest, errors.New("status must be pending, approved or rejected").Error())
+		return
+	}
+
+	if status != models.ReviewStatusApproved && !h.canModerateReviews(c) {
+		c.JSON(http.StatusForbidden, errors.New("missing permission "+reviewModeratePermission).Error())
+		return
+	}
+
+	resp, err := h.storage.Review().GetList(context.Background(), &models.GetListReviewRequest{
+		Limit:  limit,
+		Offset: offset,
+		FilmId: c.Param("id"),
+		Status: status,
+		Sort:   sort,
+	})
+
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetByIdReview godoc
+// @ID get_by_id_review
+// @Router /review/{id} [GET]
+// @Summary Get By Id Review
+// @Description Reviews that are not approved are only visible to their author and moderators
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Review "GetReviewBody"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetReviewById(c *gin.Context) {
+
+	resp, ok := h.getVisibleReview(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateReviewStatus godoc
+// @ID update_review_status
+// @Router /review/{id}/status [PUT]
+// @Summary Moderate Review
+// @Description Approve or reject a review. Only approved reviews count towards the rating of the film.
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param status body models.UpdateReviewStatus true "UpdateReviewStatusRequestBody"
+// @Success 200 {object} models.Review "GetReviewBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UpdateReviewStatus(c *gin.Context) {
+
+	var (
+		status models.UpdateReviewStatus
+	)
+
+	id := c.Param("id")
+
+	err := c.ShouldBindJSON(&status)
+	if err != nil {
+		log.Printf("error whiling update: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = status.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rowsAffected, err := h.storage.Review().SetStatus(context.Background(), id, &status)
+	if err != nil {
+		log.Printf("error whiling SetStatus: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling SetStatus").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("review not found").Error())
+		return
+	}
+
+	resp, err := h.storage.Review().GetByPKey(
+		context.Background(),
+		&models.ReviewPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// VoteReview godoc
+// @ID vote_review
+// @Router /review/{id}/helpful [PUT]
+// @Summary Vote Review Helpful
+// @Description Mark a review as helpful for the authenticated user, or take that back with helpful false
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param vote body models.ReviewVote true "ReviewVoteRequestBody"
+// @Success 200 {object} models.Review "GetReviewBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 403 {object} string "User Account Required"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) VoteReview(c *gin.Context) {
+	var vote models.ReviewVote
+
+	identity := getIdentity(c)
+	if identity.Kind != "user" {
+		c.JSON(http.StatusForbidden, errors.New("user account required").Error())
+		return
+	}
+
+	err := c.ShouldBindJSON(&vote)
+	if err != nil {
+		log.Printf("error whiling vote: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	review, ok := h.getVisibleReview(c)
+	if !ok {
+		return
+	}
+
+	vote.ReviewId = review.Id
+	vote.UserId = identity.Subject
+
+	err = h.storage.Review().Vote(context.Background(), &vote)
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusNotFound, errors.New("review not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Vote: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Vote").Error())
+		return
+	}
+
+	resp, err := h.storage.Review().GetByPKey(
+		context.Background(),
+		&models.ReviewPrimarKey{Id: review.Id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getVisibleReview loads the review of the path and responds with 404 when
+// it does not exist or the caller may not see it yet.
+func (h *HandlerV1) getVisibleReview(c *gin.Context) (*models.Review, bool) {
+
+	resp, err := h.storage.Review().GetByPKey(
+		context.Background(),
+		&models.ReviewPrimarKey{Id: c.Param("id")},
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("review not found").Error())
+		return nil, false
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return nil, false
+	}
+
+	identity := getIdentity(c)
+
+	visible := resp.Status == models.ReviewStatusApproved ||
+		(identity.Kind == "user" && identity.Subject == resp.UserId) ||
+		h.canModerateReviews(c)
+
+	if !visible {
+		c.JSON(http.StatusNotFound, errors.New("review not found").Error())
+		return nil, false
+	}
+
+	return resp, true
+}
+
+func (h *HandlerV1) canModerateReviews(c *gin.Context) bool {
+
+	identity := getIdentity(c)
+
+	return h.policy.Allowed(identity.Roles, identity.Permissions, reviewModeratePermission)
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"crud/models"
+	"crud/pkg/rbac"
+)
+
+var searchTypes = []string{
+	models.SearchTypeFilm,
+	models.SearchTypeActor,
+	models.SearchTypeCategory,
+}
+
+// Search godoc
+// @ID search
+// @Router /search [GET]
+// @Summary Search
+// @Description Full-text search over film title and description, actor names and category names. Hits are ranked, highlighted and grouped by type. Headlines and snippets are HTML-escaped with the matches wrapped in <mark>. The last word matches as a prefix.
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param q query string true "query"
+// @Param types query string false "comma separated film,actor,category, all when empty"
+// @Param offset query string false "offset per type"
+// @Param limit query string false "limit per type"
+// @Success 200 {object} models.SearchResponse "SearchBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) Search(c *gin.Context) {
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, errors.New("required q").Error())
+		return
+	}
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling pagination: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var (
+		identity = getIdentity(c)
+		types    = searchTypes
+		allowed  []string
+	)
+
+	if c.Query("types") != "" {
+		types = strings.Split(c.Query("types"), ",")
+	}
+
+	for _, typ := range types {
+		typ = strings.TrimSpace(typ)
+
+		if !contains(searchTypes, typ) {
+			c.JSON(http.StatusBadRequest, fmt.Sprintf("unsupported type %q", typ))
+			return
+		}
+
+		// Types the caller can not read are silently left out.
+		if h.policy.Allowed(identity.Roles, identity.Permissions, rbac.Permission(typ, rbac.ActionRead)) {
+			allowed = append(allowed, typ)
+		}
+	}
+
+	resp, err := h.storage.Search().Search(
+		context.Background(),
+		&models.SearchRequest{
+			Query:  query,
+			Types:  allowed,
+			Limit:  limit,
+			Offset: offset,
+		},
+	)
+
+	if err != nil {
+		log.Printf("error whiling search: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling search").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func contains(values []string, value string) bool {
+
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+// CreateStaff godoc
+// @ID create_staff
+// @Router /staff [POST]
+// @Summary Create Staff
+// @Description Add a staff member to a store. user_id links the login account that rentals and payments are attributed to.
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param staff body models.CreateStaff true "CreateStaffRequestBody"
+// @Success 201 {object} models.Staff "GetStaffBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Email or User Already Linked"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateStaff(c *gin.Context) {
+	var staff models.CreateStaff
+
+	err := c.ShouldBindJSON(&staff)
+	if err != nil {
+		log.Printf("error whiling create: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = staff.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.storage.Staff().Create(context.Background(), &staff)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("email or user already linked to a staff member").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("store, user or manager not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Create: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
+		return
+	}
+
+	resp, err := h.storage.Staff().GetByPKey(
+		context.Background(),
+		&models.StaffPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetByIdStaff godoc
+// @ID get_by_id_staff
+// @Router /staff/{id} [GET]
+// @Summary Get By Id Staff
+// @Description Get By Id Staff
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Staff "GetStaffBody"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetStaffById(c *gin.Context) {
+
+	id := c.Param("id")
+
+	resp, err := h.storage.Staff().GetByPKey(
+		context.Background(),
+		&models.StaffPrimarKey{Id: id},
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("staff not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetListStaff godoc
+// @ID get_list_staff
+// @Router /staff [GET]
+// @Summary Get List Staff
+// @Description Get List Staff
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param store_id query string false "store_id"
+// @Param manager_id query string false "manager_id"
+// @Param active query bool false "active"
+// @Success 200 {object} models.GetListStaffResponse "GetStaffBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetStaffList(c *gin.Context) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := &models.GetListStaffRequest{
+		Limit:     limit,
+		Offset:    offset,
+		StoreId:   c.Query("store_id"),
+		ManagerId: c.Query("manager_id"),
+	}
+
+	if value := c.Query("active"); value != "" {
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errors.New("active must be true or false").Error())
+			return
+		}
+		req.Active = &active
+	}
+
+	resp, err := h.storage.Staff().GetList(context.Background(), req)
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateStaff godoc
+// @ID update_staff
+// @Router /staff/{id} [PUT]
+// @Summary Update Staff
+// @Description Replace the staff member. active is kept when it is left out.
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param staff body models.UpdateStaff true "UpdateStaffRequestBody"
+// @Success 200 {object} models.Staff "GetStaffBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "Email or User Already Linked"
+// @Response 422 {object} string "Manager Cycle"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UpdateStaff(c *gin.Context) {
+
+	var (
+		staff models.UpdateStaff
+	)
+
+	id := c.Param("id")
+
+	err := c.ShouldBindJSON(&staff)
+	if err != nil {
+		log.Printf("error whiling update: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = staff.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rowsAffected, err := h.storage.Staff().Update(context.Background(), id, &staff)
+	if errors.Is(err, storage.ErrManagerCycle) {
+		c.JSON(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("email or user already linked to a staff member").Error())
+		return
+	}
+
+	if errors.Is(err, storage.ErrReferenceNotFound) {
+		c.JSON(http.StatusBadRequest, errors.New("store, user or manager not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling update: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling update").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("staff not found").Error())
+		return
+	}
+
+	resp, err := h.storage.Staff().GetByPKey(
+		context.Background(),
+		&models.StaffPrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteByIdStaff godoc
+// @ID delete_by_id_staff
+// @Router /staff/{id} [DELETE]
+// @Summary Delete By Id Staff
+// @Description Delete the staff member. Staff who handled rentals or payments should be deactivated instead.
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "In Use"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) DeleteStaff(c *gin.Context) {
+
+	id := c.Param("id")
+
+	rowsAffected, err := h.storage.Staff().Delete(
+		context.Background(),
+		&models.StaffPrimarKey{
+			Id: id,
+		},
+	)
+
+	if errors.Is(err, storage.ErrInUse) {
+		c.JSON(http.StatusConflict, errors.New("staff member handled rentals or payments, deactivate it instead").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling delete: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling delete").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("staff not found").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
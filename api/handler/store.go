@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+// CreateStore godoc
+// @ID create_store
+// @Router /store [POST]
+// @Summary Create Store
+// @Description Create Store
+// @Tags Store
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Idempotency-Key"
+// @Param store body models.CreateStore true "CreateStoreRequestBody"
+// @Success 201 {object} models.Store "GetStoreBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 409 {object} string "Already Exists"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) CreateStore(c *gin.Context) {
+	var store models.CreateStore
+
+	err := c.ShouldBindJSON(&store)
+	if err != nil {
+		log.Printf("error whiling create: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = store.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.storage.Store().Create(context.Background(), &store)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("store already exists").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling Create: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling Create").Error())
+		return
+	}
+
+	resp, err := h.storage.Store().GetByPKey(
+		context.Background(),
+		&models.StorePrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetByIdStore godoc
+// @ID get_by_id_store
+// @Router /store/{id} [GET]
+// @Summary Get By Id Store
+// @Description Get By Id Store
+// @Tags Store
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Store "GetStoreBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetStoreById(c *gin.Context) {
+
+	id := c.Param("id")
+
+	resp, err := h.storage.Store().GetByPKey(
+		context.Background(),
+		&models.StorePrimarKey{Id: id},
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, errors.New("store not found").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetListStore godoc
+// @ID get_list_store
+// @Router /store [GET]
+// @Summary Get List Store
+// @Description Get List Store
+// @Tags Store
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.GetListStoreResponse "GetStoreBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) GetStoreList(c *gin.Context) {
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		log.Printf("error whiling list request: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.storage.Store().GetList(context.Background(), &models.GetListStoreRequest{
+		Limit:  limit,
+		Offset: offset,
+	})
+
+	if err != nil {
+		log.Printf("error whiling get list: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling get list").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateStore godoc
+// @ID update_store
+// @Router /store/{id} [PUT]
+// @Summary Update Store
+// @Description Update Store
+// @Tags Store
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param store body models.UpdateStore true "UpdateStoreRequestBody"
+// @Success 200 {object} models.Store "GetStoreBody"
+// @Response 400 {object} string "Invalid Argument"
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "Already Exists"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) UpdateStore(c *gin.Context) {
+
+	var (
+		store models.UpdateStore
+	)
+
+	id := c.Param("id")
+
+	err := c.ShouldBindJSON(&store)
+	if err != nil {
+		log.Printf("error whiling update: %v\n", err)
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = store.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rowsAffected, err := h.storage.Store().Update(
+		context.Background(),
+		id,
+		&store,
+	)
+
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, errors.New("store already exists").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling update: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling update").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("store not found").Error())
+		return
+	}
+
+	resp, err := h.storage.Store().GetByPKey(
+		context.Background(),
+		&models.StorePrimarKey{Id: id},
+	)
+
+	if err != nil {
+		log.Printf("error whiling GetByPKey: %v\n", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling GetByPKey").Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteByIdStore godoc
+// @ID delete_by_id_store
+// @Router /store/{id} [DELETE]
+// @Summary Delete By Id Store
+// @Description Delete the store together with its inventory. Fails while customers still belong to it.
+// @Tags Store
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204
+// @Response 404 {object} string "Not Found"
+// @Response 409 {object} string "In Use"
+// @Failure 500 {object} string "Server Error"
+func (h *HandlerV1) DeleteStore(c *gin.Context) {
+
+	id := c.Param("id")
+
+	rowsAffected, err := h.storage.Store().Delete(
+		context.Background(),
+		&models.StorePrimarKey{
+			Id: id,
+		},
+	)
+
+	if errors.Is(err, storage.ErrInUse) {
+		c.JSON(http.StatusConflict, errors.New("store still has customers, staff or rentals").Error())
+		return
+	}
+
+	if err != nil {
+		log.Printf("error whiling delete: %v", err)
+		c.JSON(http.StatusInternalServerError, errors.New("error whiling delete").Error())
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, errors.New("store not found").Error())
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -6,3 +6,48 @@ type Response struct {
 	Description string      `json:"description"`
 	Data        interface{} `json:"data"`
 }
+
+// Status ...
+type Status struct {
+	Code        int
+	Status      string
+	Description string
+}
+
+var (
+	BadRequest = Status{
+		Code:        400,
+		Status:      "BAD_REQUEST",
+		Description: "Bad Request",
+	}
+	Unauthorized = Status{
+		Code:        401,
+		Status:      "UNAUTHORIZED",
+		Description: "Unauthorized",
+	}
+	Forbidden = Status{
+		Code:        403,
+		Status:      "FORBIDDEN",
+		Description: "Forbidden",
+	}
+	TooManyRequests = Status{
+		Code:        429,
+		Status:      "TOO_MANY_REQUESTS",
+		Description: "Too Many Requests",
+	}
+	Conflict = Status{
+		Code:        409,
+		Status:      "CONFLICT",
+		Description: "Conflict",
+	}
+	UnprocessableEntity = Status{
+		Code:        422,
+		Status:      "UNPROCESSABLE_ENTITY",
+		Description: "Unprocessable Entity",
+	}
+	InternalServerError = Status{
+		Code:        500,
+		Status:      "INTERNAL_SERVER_ERROR",
+		Description: "Internal Server Error",
+	}
+)
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"crud/config"
+	"crud/pkg/importer"
+	"crud/storage/postgres"
+)
+
+// runImport implements `import [-format csv|ndjson] [-dry-run] <entity> <file>`.
+func runImport(cfg config.Config, args []string) error {
+
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	format := flags.String("format", importer.FormatCSV, "csv or ndjson")
+	dryRun := flags.Bool("dry-run", false, "validate rows without writing them")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() != 2 {
+		return fmt.Errorf("usage: import [-format csv|ndjson] [-dry-run] <film|actor|category> <file>")
+	}
+
+	file, err := os.Open(flags.Arg(1))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	storage, err := postgres.NewPostgres(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer storage.CloseDB()
+
+	target, err := importer.NewTarget(flags.Arg(0), storage)
+	if err != nil {
+		return err
+	}
+
+	decoder, err := importer.NewDecoder(file, *format)
+	if err != nil {
+		return err
+	}
+
+	report, err := importer.Run(context.Background(), decoder, target, *format, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
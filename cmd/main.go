@@ -3,10 +3,17 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"crud/api"
 	"crud/config"
+	"crud/pkg/media"
+	"crud/pkg/outbox"
+	"crud/pkg/ratelimit"
+	"crud/pkg/rbac"
+	"crud/pkg/reports"
 	"crud/storage/postgres"
 )
 
@@ -14,6 +21,14 @@ func main() {
 
 	cfg := config.Load()
 
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		err := runImport(cfg, os.Args[2:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	r := gin.New()
 
 	r.Use(gin.Logger(), gin.Recovery())
@@ -24,7 +39,39 @@ func main() {
 	}
 	defer storage.CloseDB()
 
-	api.SetUpApi(r, storage)
+	go func() {
+		for range time.Tick(time.Hour) {
+			deleted, err := storage.IdempotencyKey().DeleteExpired(context.Background())
+			if err != nil {
+				log.Printf("error whiling delete expired idempotency keys: %v\n", err)
+				continue
+			}
+			log.Printf("deleted %d expired idempotency keys\n", deleted)
+		}
+	}()
+
+	go reports.RefreshEvery(cfg.ReportRefreshInterval, storage.Report())
+
+	go func() {
+		for range time.Tick(cfg.RecommendationRefreshInterval) {
+			err := storage.Recommendation().Refresh(context.Background())
+			if err != nil {
+				log.Printf("error whiling refresh recommendations: %v\n", err)
+			}
+		}
+	}()
+
+	policy, err := rbac.LoadPolicy(cfg.RBACPolicyPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	blobs := media.NewLocalStore(cfg.MediaDir, cfg.MediaBaseURL)
+	if cfg.MediaStorage == "s3" {
+		blobs = media.NewS3Store(cfg.S3)
+	}
+
+	api.SetUpApi(r, cfg, storage, policy, outbox.New(cfg.OutboxPath), ratelimit.NewMemoryStore(), blobs)
 
 	log.Printf("Listening port %v...\n", cfg.HTTPPort)
 	err = r.Run(cfg.HTTPPort)
@@ -1,5 +1,16 @@
 package config
 
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"crud/models"
+	"crud/pkg/fee"
+	"crud/pkg/media"
+	"crud/pkg/ratelimit"
+)
+
 type Config struct {
 	HTTPPort string
 
@@ -9,6 +20,46 @@ type Config struct {
 	PostgresPassword       string
 	PostgresPort           string
 	PostgresMaxConnections int32
+
+	SecretKey      string
+	RBACPolicyPath string
+
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+	PasswordResetTTL time.Duration
+	OutboxPath       string
+
+	// RateLimits holds the limit for each route group, "default" is used
+	// for groups that are not listed.
+	RateLimits map[string]ratelimit.Limit
+
+	IdempotencyKeyTTL time.Duration
+
+	BatchMaxItems int
+
+	ActorSuggestThreshold   float32
+	ActorDuplicateThreshold float32
+
+	LateFees fee.Policy
+
+	// ReportRefreshInterval is how often the materialized views behind the
+	// heavy reports are refreshed.
+	ReportRefreshInterval time.Duration
+
+	SimilarityWeights             models.SimilarityWeights
+	RecommendationRefreshInterval time.Duration
+
+	// MediaStorage is "local" to keep uploads under MediaDir, served from
+	// MediaBaseURL, or "s3" to keep them in the S3 bucket.
+	MediaStorage string
+	MediaDir     string
+	MediaBaseURL string
+	S3           media.S3Config
+
+	MaxPosterSize  int64
+	MaxTrailerSize int64
+	MaxPhotoSize   int64
+	ThumbnailWidth int
 }
 
 func Load() Config {
@@ -24,5 +75,49 @@ func Load() Config {
 	cfg.PostgresPort = "5432"
 	cfg.PostgresMaxConnections = 20
 
+	cfg.SecretKey = "samandevop-secret"
+	cfg.RBACPolicyPath = "./config/rbac_policy.json"
+
+	cfg.AccessTokenTTL = 15 * time.Minute
+	cfg.RefreshTokenTTL = 30 * 24 * time.Hour
+	cfg.PasswordResetTTL = time.Hour
+	cfg.OutboxPath = "./outbox.log"
+
+	cfg.RateLimits = map[string]ratelimit.Limit{
+		"default": {Rate: 10, Burst: 20},
+		"film":    {Rate: 5, Burst: 10},
+		"auth":    {Rate: 1, Burst: 5},
+	}
+
+	cfg.IdempotencyKeyTTL = 24 * time.Hour
+
+	cfg.BatchMaxItems = 5000
+
+	cfg.ActorSuggestThreshold = 0.3
+	cfg.ActorDuplicateThreshold = 0.7
+
+	cfg.LateFees = fee.Policy{PerDay: decimal.RequireFromString("1.00")}
+
+	cfg.ReportRefreshInterval = 15 * time.Minute
+
+	cfg.SimilarityWeights = models.SimilarityWeights{Actor: 2, Category: 1, CoRenter: 1}
+	cfg.RecommendationRefreshInterval = time.Hour
+
+	cfg.MediaStorage = "local"
+	cfg.MediaDir = "./media"
+	cfg.MediaBaseURL = "http://localhost:4000/media"
+	cfg.S3 = media.S3Config{
+		Endpoint:  "http://localhost:9000",
+		Region:    "us-east-1",
+		Bucket:    "crud-media",
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+	}
+
+	cfg.MaxPosterSize = 5 << 20
+	cfg.MaxTrailerSize = 200 << 20
+	cfg.MaxPhotoSize = 5 << 20
+	cfg.ThumbnailWidth = 300
+
 	return cfg
 }
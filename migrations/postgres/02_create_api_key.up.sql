@@ -0,0 +1,13 @@
+
+CREATE TABLE api_key (
+    api_key_id UUID PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    scopes VARCHAR[] DEFAULT '{}' NOT NULL,
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
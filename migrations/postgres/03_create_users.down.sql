@@ -0,0 +1,4 @@
+
+DROP TABLE IF EXISTS password_reset_token;
+DROP TABLE IF EXISTS refresh_token;
+DROP TABLE IF EXISTS users;
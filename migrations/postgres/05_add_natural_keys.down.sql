@@ -0,0 +1,4 @@
+
+DROP INDEX IF EXISTS category_name_key;
+DROP INDEX IF EXISTS actor_first_name_last_name_idx;
+DROP INDEX IF EXISTS film_title_release_year_key;
@@ -0,0 +1,42 @@
+
+-- Films sharing a title and release year and categories sharing a name cannot
+-- be told apart by the new keys. Which row to keep is not the migration's call,
+-- so it fails listing the conflicts and deletes nothing. Rename or delete the
+-- duplicates and run it again.
+DO $$
+DECLARE
+    films TEXT;
+    categories TEXT;
+BEGIN
+    SELECT string_agg(format('%s (%s) x%s', title, release_year, n), ', ')
+    INTO films
+    FROM (
+        SELECT title, release_year, COUNT(*) AS n
+        FROM film
+        GROUP BY title, release_year
+        HAVING COUNT(*) > 1
+    ) duplicate;
+
+    SELECT string_agg(format('%s x%s', name, n), ', ')
+    INTO categories
+    FROM (
+        SELECT name, COUNT(*) AS n
+        FROM category
+        GROUP BY name
+        HAVING COUNT(*) > 1
+    ) duplicate;
+
+    IF films IS NOT NULL OR categories IS NOT NULL THEN
+        RAISE EXCEPTION 'duplicate natural keys, rename or delete them first'
+            USING DETAIL = format('films: %s; categories: %s', COALESCE(films, 'none'), COALESCE(categories, 'none'));
+    END IF;
+END
+$$;
+
+CREATE UNIQUE INDEX film_title_release_year_key ON film(title, release_year);
+
+-- Different people can share a name and duplicate actors are merged through
+-- the API, so the actor name only gets a lookup index.
+CREATE INDEX actor_first_name_last_name_idx ON actor(first_name, last_name);
+
+CREATE UNIQUE INDEX category_name_key ON category(name);
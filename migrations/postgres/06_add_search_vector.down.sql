@@ -0,0 +1,9 @@
+
+DROP INDEX IF EXISTS category_search_vector_idx;
+ALTER TABLE category DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS actor_search_vector_idx;
+ALTER TABLE actor DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS film_search_vector_idx;
+ALTER TABLE film DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,20 @@
+
+ALTER TABLE film ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+) STORED;
+
+CREATE INDEX film_search_vector_idx ON film USING GIN (search_vector);
+
+ALTER TABLE actor ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', COALESCE(last_name, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(first_name, '')), 'B')
+) STORED;
+
+CREATE INDEX actor_search_vector_idx ON actor USING GIN (search_vector);
+
+ALTER TABLE category ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    to_tsvector('simple', COALESCE(name, ''))
+) STORED;
+
+CREATE INDEX category_search_vector_idx ON category USING GIN (search_vector);
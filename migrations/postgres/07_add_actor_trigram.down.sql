@@ -0,0 +1,2 @@
+
+DROP INDEX IF EXISTS actor_full_name_trgm_idx;
@@ -0,0 +1,4 @@
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX actor_full_name_trgm_idx ON actor USING GIN ((first_name || ' ' || last_name) gin_trgm_ops);
@@ -0,0 +1,7 @@
+
+DROP TABLE IF EXISTS actor_merge;
+DROP TABLE IF EXISTS film_actor;
+
+ALTER TABLE category DROP CONSTRAINT IF EXISTS category_pkey;
+ALTER TABLE actor DROP CONSTRAINT IF EXISTS actor_pkey;
+ALTER TABLE film DROP CONSTRAINT IF EXISTS film_pkey;
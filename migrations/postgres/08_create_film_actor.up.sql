@@ -0,0 +1,24 @@
+
+ALTER TABLE film ADD PRIMARY KEY (film_id);
+ALTER TABLE actor ADD PRIMARY KEY (actor_id);
+ALTER TABLE category ADD PRIMARY KEY (category_id);
+
+CREATE TABLE film_actor (
+    film_id UUID NOT NULL REFERENCES film(film_id) ON DELETE CASCADE,
+    actor_id UUID NOT NULL REFERENCES actor(actor_id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (film_id, actor_id)
+);
+
+CREATE INDEX film_actor_actor_id_idx ON film_actor(actor_id);
+
+CREATE TABLE actor_merge (
+    merged_actor_id UUID PRIMARY KEY,
+    survivor_actor_id UUID NOT NULL REFERENCES actor(actor_id) ON DELETE CASCADE,
+    first_name character varying(45) NOT NULL,
+    last_name character varying(45) NOT NULL,
+    merged_by VARCHAR,
+    merged_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX actor_merge_survivor_actor_id_idx ON actor_merge(survivor_actor_id);
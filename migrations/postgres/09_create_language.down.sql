@@ -0,0 +1,6 @@
+
+ALTER TABLE film
+    DROP COLUMN IF EXISTS original_language_id,
+    DROP COLUMN IF EXISTS language_id;
+
+DROP TABLE IF EXISTS language;
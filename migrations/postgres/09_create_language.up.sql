@@ -0,0 +1,16 @@
+
+CREATE TABLE language (
+    language_id UUID PRIMARY KEY,
+    name character varying(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX language_name_key ON language(name);
+
+ALTER TABLE film
+    ADD COLUMN language_id UUID REFERENCES language(language_id) ON DELETE SET NULL,
+    ADD COLUMN original_language_id UUID REFERENCES language(language_id) ON DELETE SET NULL;
+
+CREATE INDEX film_language_id_idx ON film(language_id);
+CREATE INDEX film_original_language_id_idx ON film(original_language_id);
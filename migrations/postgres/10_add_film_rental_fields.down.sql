@@ -0,0 +1,9 @@
+
+ALTER TABLE film
+    DROP COLUMN IF EXISTS special_features,
+    DROP COLUMN IF EXISTS replacement_cost,
+    DROP COLUMN IF EXISTS rental_rate,
+    DROP COLUMN IF EXISTS rental_duration,
+    DROP COLUMN IF EXISTS rating;
+
+DROP TYPE IF EXISTS mpaa_rating;
@@ -0,0 +1,20 @@
+
+CREATE TYPE mpaa_rating AS ENUM ('G', 'PG', 'PG-13', 'R', 'NC-17');
+
+ALTER TABLE film
+    ADD COLUMN rating mpaa_rating DEFAULT 'G' NOT NULL,
+    ADD COLUMN rental_duration SMALLINT DEFAULT 3 NOT NULL,
+    ADD COLUMN rental_rate NUMERIC(4,2) DEFAULT 4.99 NOT NULL,
+    ADD COLUMN replacement_cost NUMERIC(5,2) DEFAULT 19.99 NOT NULL,
+    ADD COLUMN special_features TEXT[] DEFAULT '{}' NOT NULL;
+
+ALTER TABLE film
+    ADD CONSTRAINT film_rental_duration_check CHECK (rental_duration >= 0),
+    ADD CONSTRAINT film_rental_rate_check CHECK (rental_rate >= 0),
+    ADD CONSTRAINT film_replacement_cost_check CHECK (replacement_cost >= 0),
+    ADD CONSTRAINT film_special_features_check CHECK (
+        special_features <@ ARRAY['Trailers', 'Commentaries', 'Deleted Scenes', 'Behind the Scenes']
+    );
+
+CREATE INDEX film_rating_idx ON film(rating);
+CREATE INDEX film_rental_rate_idx ON film(rental_rate);
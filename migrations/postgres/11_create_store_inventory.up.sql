@@ -0,0 +1,21 @@
+
+CREATE TABLE store (
+    store_id UUID PRIMARY KEY,
+    name character varying(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX store_name_key ON store(name);
+
+CREATE TABLE inventory (
+    inventory_id UUID PRIMARY KEY,
+    film_id UUID NOT NULL REFERENCES film(film_id) ON DELETE CASCADE,
+    store_id UUID NOT NULL REFERENCES store(store_id) ON DELETE CASCADE,
+    status VARCHAR(16) DEFAULT 'available' NOT NULL CHECK (status IN ('available', 'rented')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX inventory_film_id_store_id_idx ON inventory(film_id, store_id);
+CREATE INDEX inventory_store_id_idx ON inventory(store_id);
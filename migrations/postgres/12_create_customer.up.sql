@@ -0,0 +1,49 @@
+
+CREATE TABLE country (
+    country_id UUID PRIMARY KEY,
+    name character varying(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX country_name_key ON country(name);
+
+CREATE TABLE city (
+    city_id UUID PRIMARY KEY,
+    name character varying(50) NOT NULL,
+    country_id UUID NOT NULL REFERENCES country(country_id) ON DELETE RESTRICT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX city_country_id_name_key ON city(country_id, name);
+
+CREATE TABLE address (
+    address_id UUID PRIMARY KEY,
+    address character varying(50) NOT NULL,
+    address2 character varying(50),
+    district character varying(20) NOT NULL,
+    city_id UUID NOT NULL REFERENCES city(city_id) ON DELETE RESTRICT,
+    postal_code character varying(10),
+    phone character varying(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX address_city_id_idx ON address(city_id);
+
+CREATE TABLE customer (
+    customer_id UUID PRIMARY KEY,
+    store_id UUID NOT NULL REFERENCES store(store_id) ON DELETE RESTRICT,
+    first_name character varying(45) NOT NULL,
+    last_name character varying(45) NOT NULL,
+    email character varying(50) NOT NULL,
+    address_id UUID NOT NULL REFERENCES address(address_id),
+    active BOOLEAN DEFAULT TRUE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX customer_email_key ON customer(email);
+CREATE INDEX customer_store_id_idx ON customer(store_id);
+CREATE INDEX customer_address_id_idx ON customer(address_id);
@@ -0,0 +1,15 @@
+
+CREATE TABLE rental (
+    rental_id UUID PRIMARY KEY,
+    inventory_id UUID NOT NULL REFERENCES inventory(inventory_id) ON DELETE RESTRICT,
+    customer_id UUID NOT NULL REFERENCES customer(customer_id) ON DELETE RESTRICT,
+    rental_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    due_date TIMESTAMP NOT NULL,
+    return_date TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- A copy can only be out once at a time, on top of the row lock taken at checkout.
+CREATE UNIQUE INDEX rental_open_inventory_id_key ON rental(inventory_id) WHERE return_date IS NULL;
+CREATE INDEX rental_customer_id_rental_date_idx ON rental(customer_id, rental_date DESC);
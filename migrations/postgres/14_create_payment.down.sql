@@ -0,0 +1,6 @@
+
+DROP TABLE IF EXISTS payment;
+
+ALTER TABLE rental
+    DROP COLUMN IF EXISTS late_fee,
+    DROP COLUMN IF EXISTS rental_fee;
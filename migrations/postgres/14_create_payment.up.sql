@@ -0,0 +1,20 @@
+
+ALTER TABLE rental
+    ADD COLUMN rental_fee NUMERIC(5,2) DEFAULT 0 NOT NULL,
+    ADD COLUMN late_fee NUMERIC(5,2) DEFAULT 0 NOT NULL;
+
+CREATE TABLE payment (
+    payment_id UUID PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customer(customer_id) ON DELETE RESTRICT,
+    rental_id UUID NOT NULL REFERENCES rental(rental_id) ON DELETE RESTRICT,
+    amount NUMERIC(6,2) NOT NULL CHECK (amount <> 0),
+    refund_of UUID REFERENCES payment(payment_id) ON DELETE RESTRICT,
+    payment_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    -- Payments are positive, refunds are negative and point at the payment they refund.
+    CHECK ((refund_of IS NULL) = (amount > 0))
+);
+
+CREATE INDEX payment_customer_id_idx ON payment(customer_id);
+CREATE INDEX payment_rental_id_idx ON payment(rental_id);
+CREATE INDEX payment_refund_of_idx ON payment(refund_of);
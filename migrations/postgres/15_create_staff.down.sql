@@ -0,0 +1,9 @@
+
+ALTER TABLE payment
+    DROP COLUMN IF EXISTS staff_id;
+
+ALTER TABLE rental
+    DROP COLUMN IF EXISTS return_staff_id,
+    DROP COLUMN IF EXISTS staff_id;
+
+DROP TABLE IF EXISTS staff;
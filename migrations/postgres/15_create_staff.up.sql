@@ -0,0 +1,31 @@
+
+CREATE TABLE staff (
+    staff_id UUID PRIMARY KEY,
+    store_id UUID NOT NULL REFERENCES store(store_id) ON DELETE RESTRICT,
+    user_id UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    manager_id UUID REFERENCES staff(staff_id) ON DELETE SET NULL,
+    first_name character varying(45) NOT NULL,
+    last_name character varying(45) NOT NULL,
+    email character varying(50) NOT NULL,
+    active BOOLEAN DEFAULT TRUE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CHECK (manager_id <> staff_id)
+);
+
+CREATE UNIQUE INDEX staff_email_key ON staff(email);
+CREATE UNIQUE INDEX staff_user_id_key ON staff(user_id);
+CREATE INDEX staff_store_id_idx ON staff(store_id);
+CREATE INDEX staff_manager_id_idx ON staff(manager_id);
+
+-- Rows written before staff existed keep a NULL staff, new writes always set it.
+ALTER TABLE rental
+    ADD COLUMN staff_id UUID REFERENCES staff(staff_id) ON DELETE RESTRICT,
+    ADD COLUMN return_staff_id UUID REFERENCES staff(staff_id) ON DELETE RESTRICT;
+
+ALTER TABLE payment
+    ADD COLUMN staff_id UUID REFERENCES staff(staff_id) ON DELETE RESTRICT;
+
+CREATE INDEX rental_staff_id_idx ON rental(staff_id);
+CREATE INDEX rental_return_staff_id_idx ON rental(return_staff_id);
+CREATE INDEX payment_staff_id_idx ON payment(staff_id);
@@ -0,0 +1,9 @@
+
+CREATE TABLE film_category (
+    film_id UUID NOT NULL REFERENCES film(film_id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES category(category_id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (film_id, category_id)
+);
+
+CREATE INDEX film_category_category_id_idx ON film_category(category_id);
@@ -0,0 +1,4 @@
+
+DROP MATERIALIZED VIEW IF EXISTS report_actor_popularity;
+DROP MATERIALIZED VIEW IF EXISTS report_category_revenue;
+DROP VIEW IF EXISTS report_film_stats;
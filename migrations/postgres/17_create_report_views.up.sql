@@ -0,0 +1,49 @@
+
+-- Rentals and net payments per film, the base of the heavier reports.
+CREATE VIEW report_film_stats AS
+    SELECT
+        i.film_id,
+        COUNT(*) AS rentals,
+        COALESCE(SUM(p.amount), 0) AS revenue
+    FROM
+        rental r
+    JOIN inventory i ON i.inventory_id = r.inventory_id
+    LEFT JOIN (
+        SELECT rental_id, SUM(amount) AS amount FROM payment GROUP BY rental_id
+    ) p ON p.rental_id = r.rental_id
+    GROUP BY i.film_id;
+
+-- A film in several categories counts towards each of them.
+CREATE MATERIALIZED VIEW report_category_revenue AS
+    SELECT
+        c.category_id,
+        c.name,
+        COUNT(fc.film_id) AS films,
+        COALESCE(SUM(fs.rentals), 0)::INTEGER AS rentals,
+        COALESCE(SUM(fs.revenue), 0) AS revenue,
+        now()::TIMESTAMP AS refreshed_at
+    FROM
+        category c
+    LEFT JOIN film_category fc ON fc.category_id = c.category_id
+    LEFT JOIN report_film_stats fs ON fs.film_id = fc.film_id
+    GROUP BY c.category_id, c.name;
+
+CREATE UNIQUE INDEX report_category_revenue_category_id_key ON report_category_revenue(category_id);
+
+CREATE MATERIALIZED VIEW report_actor_popularity AS
+    SELECT
+        a.actor_id,
+        a.first_name,
+        a.last_name,
+        COUNT(fa.film_id) AS films,
+        COALESCE(SUM(fs.rentals), 0)::INTEGER AS rentals,
+        COALESCE(SUM(fs.revenue), 0) AS revenue,
+        now()::TIMESTAMP AS refreshed_at
+    FROM
+        actor a
+    LEFT JOIN film_actor fa ON fa.actor_id = a.actor_id
+    LEFT JOIN report_film_stats fs ON fs.film_id = fa.film_id
+    GROUP BY a.actor_id, a.first_name, a.last_name;
+
+CREATE UNIQUE INDEX report_actor_popularity_actor_id_key ON report_actor_popularity(actor_id);
+CREATE INDEX report_actor_popularity_rentals_idx ON report_actor_popularity(rentals DESC);
@@ -0,0 +1,7 @@
+
+ALTER TABLE film
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS average_rating;
+
+DROP TABLE IF EXISTS review_vote;
+DROP TABLE IF EXISTS review;
@@ -0,0 +1,29 @@
+
+-- Reviews outlive the account of their author, user_id becomes NULL, so that
+-- the approved ones keep counting towards the film rating aggregates.
+CREATE TABLE review (
+    review_id UUID PRIMARY KEY,
+    film_id UUID NOT NULL REFERENCES film(film_id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body TEXT DEFAULT '' NOT NULL,
+    status VARCHAR(10) DEFAULT 'pending' NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    helpful_count INTEGER DEFAULT 0 NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX review_film_id_user_id_key ON review(film_id, user_id);
+CREATE INDEX review_film_id_status_idx ON review(film_id, status);
+
+CREATE TABLE review_vote (
+    review_id UUID NOT NULL REFERENCES review(review_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (review_id, user_id)
+);
+
+-- Only approved reviews count, kept up to date by the review repo.
+ALTER TABLE film
+    ADD COLUMN average_rating NUMERIC(3,2),
+    ADD COLUMN rating_count INTEGER DEFAULT 0 NOT NULL;
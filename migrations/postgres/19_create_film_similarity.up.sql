@@ -0,0 +1,36 @@
+
+-- What every pair of films has in common. Recommendations weigh these at
+-- query time, the view itself is refreshed on a schedule.
+CREATE MATERIALIZED VIEW film_similarity AS
+    WITH customer_film AS (
+        SELECT DISTINCT
+            r.customer_id,
+            i.film_id
+        FROM
+            rental r
+        JOIN inventory i ON i.inventory_id = r.inventory_id
+    ), pairs AS (
+        SELECT a.film_id, b.film_id AS similar_film_id, 1 AS actors, 0 AS categories, 0 AS co_renters
+        FROM film_actor a
+        JOIN film_actor b ON b.actor_id = a.actor_id AND b.film_id <> a.film_id
+        UNION ALL
+        SELECT a.film_id, b.film_id, 0, 1, 0
+        FROM film_category a
+        JOIN film_category b ON b.category_id = a.category_id AND b.film_id <> a.film_id
+        UNION ALL
+        SELECT a.film_id, b.film_id, 0, 0, 1
+        FROM customer_film a
+        JOIN customer_film b ON b.customer_id = a.customer_id AND b.film_id <> a.film_id
+    )
+    SELECT
+        film_id,
+        similar_film_id,
+        SUM(actors)::INTEGER AS shared_actors,
+        SUM(categories)::INTEGER AS shared_categories,
+        SUM(co_renters)::INTEGER AS co_renters,
+        now()::TIMESTAMP AS refreshed_at
+    FROM
+        pairs
+    GROUP BY film_id, similar_film_id;
+
+CREATE UNIQUE INDEX film_similarity_film_id_similar_film_id_key ON film_similarity(film_id, similar_film_id);
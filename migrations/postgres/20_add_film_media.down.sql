@@ -0,0 +1,7 @@
+
+ALTER TABLE film
+    DROP COLUMN IF EXISTS trailer_url,
+    DROP COLUMN IF EXISTS trailer_key,
+    DROP COLUMN IF EXISTS poster_thumbnail_url,
+    DROP COLUMN IF EXISTS poster_url,
+    DROP COLUMN IF EXISTS poster_key;
@@ -0,0 +1,8 @@
+
+-- The keys locate the files in the blob store, the urls are what clients get.
+ALTER TABLE film
+    ADD COLUMN poster_key TEXT,
+    ADD COLUMN poster_url TEXT,
+    ADD COLUMN poster_thumbnail_url TEXT,
+    ADD COLUMN trailer_key TEXT,
+    ADD COLUMN trailer_url TEXT;
@@ -0,0 +1,16 @@
+
+DROP INDEX IF EXISTS actor_wikidata_id_key;
+DROP INDEX IF EXISTS actor_tmdb_id_key;
+DROP INDEX IF EXISTS actor_imdb_id_key;
+DROP INDEX IF EXISTS actor_birth_date_idx;
+
+ALTER TABLE actor
+    DROP CONSTRAINT IF EXISTS actor_external_ids_check,
+    DROP COLUMN IF EXISTS photo_thumbnail_url,
+    DROP COLUMN IF EXISTS photo_url,
+    DROP COLUMN IF EXISTS photo_key,
+    DROP COLUMN IF EXISTS external_ids,
+    DROP COLUMN IF EXISTS birthplace,
+    DROP COLUMN IF EXISTS birth_date,
+    DROP COLUMN IF EXISTS biography,
+    DROP COLUMN IF EXISTS display_name;
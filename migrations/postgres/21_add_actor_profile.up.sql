@@ -0,0 +1,22 @@
+
+-- display_name is NULL until set, the actor is credited as first_name
+-- last_name until then.
+ALTER TABLE actor
+    ADD COLUMN display_name VARCHAR(100),
+    ADD COLUMN biography TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN birth_date DATE,
+    ADD COLUMN birthplace VARCHAR(100) DEFAULT '' NOT NULL,
+    ADD COLUMN external_ids JSONB DEFAULT '{}' NOT NULL,
+    ADD COLUMN photo_key TEXT,
+    ADD COLUMN photo_url TEXT,
+    ADD COLUMN photo_thumbnail_url TEXT;
+
+ALTER TABLE actor
+    ADD CONSTRAINT actor_external_ids_check CHECK (jsonb_typeof(external_ids) = 'object');
+
+CREATE INDEX actor_birth_date_idx ON actor(birth_date);
+
+-- An external id belongs to one actor only.
+CREATE UNIQUE INDEX actor_imdb_id_key ON actor((external_ids ->> 'imdb'));
+CREATE UNIQUE INDEX actor_tmdb_id_key ON actor((external_ids ->> 'tmdb'));
+CREATE UNIQUE INDEX actor_wikidata_id_key ON actor((external_ids ->> 'wikidata'));
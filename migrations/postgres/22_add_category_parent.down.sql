@@ -0,0 +1,5 @@
+
+DROP INDEX IF EXISTS category_parent_id_idx;
+
+ALTER TABLE category
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,8 @@
+
+-- A category can not be deleted while it has subcategories, they have to be
+-- moved or deleted first. Cycles, including a category being its own
+-- parent, are prevented by the category repo.
+ALTER TABLE category
+    ADD COLUMN parent_id UUID REFERENCES category(category_id);
+
+CREATE INDEX category_parent_id_idx ON category(parent_id);
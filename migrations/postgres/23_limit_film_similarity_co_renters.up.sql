@@ -0,0 +1,57 @@
+
+-- Pairing every two films a customer ever rented grows with the square of
+-- their history. Only rentals of the last 12 months count as co-rentals and
+-- every film keeps its 50 most co-rented films, which is plenty for the
+-- recommendations and keeps the co-rental part linear in the number of films.
+DROP MATERIALIZED VIEW film_similarity;
+
+CREATE MATERIALIZED VIEW film_similarity AS
+    WITH customer_film AS (
+        SELECT DISTINCT
+            r.customer_id,
+            i.film_id
+        FROM
+            rental r
+        JOIN inventory i ON i.inventory_id = r.inventory_id
+        WHERE
+            r.rental_date >= now() - INTERVAL '12 months'
+    ), co_rented AS (
+        SELECT
+            film_id,
+            similar_film_id,
+            co_renters
+        FROM (
+            SELECT
+                a.film_id,
+                b.film_id AS similar_film_id,
+                COUNT(*) AS co_renters,
+                ROW_NUMBER() OVER (PARTITION BY a.film_id ORDER BY COUNT(*) DESC, b.film_id) AS n
+            FROM customer_film a
+            JOIN customer_film b ON b.customer_id = a.customer_id AND b.film_id <> a.film_id
+            GROUP BY a.film_id, b.film_id
+        ) ranked
+        WHERE n <= 50
+    ), pairs AS (
+        SELECT a.film_id, b.film_id AS similar_film_id, 1 AS actors, 0 AS categories, 0 AS co_renters
+        FROM film_actor a
+        JOIN film_actor b ON b.actor_id = a.actor_id AND b.film_id <> a.film_id
+        UNION ALL
+        SELECT a.film_id, b.film_id, 0, 1, 0
+        FROM film_category a
+        JOIN film_category b ON b.category_id = a.category_id AND b.film_id <> a.film_id
+        UNION ALL
+        SELECT film_id, similar_film_id, 0, 0, co_renters
+        FROM co_rented
+    )
+    SELECT
+        film_id,
+        similar_film_id,
+        SUM(actors)::INTEGER AS shared_actors,
+        SUM(categories)::INTEGER AS shared_categories,
+        SUM(co_renters)::INTEGER AS co_renters,
+        now()::TIMESTAMP AS refreshed_at
+    FROM
+        pairs
+    GROUP BY film_id, similar_film_id;
+
+CREATE UNIQUE INDEX film_similarity_film_id_similar_film_id_key ON film_similarity(film_id, similar_film_id);
@@ -1,32 +1,197 @@
 package models
 
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxActorNameLength        = 45
+	maxActorDisplayNameLength = 100
+	maxActorBirthplaceLength  = 100
+	maxActorBiographyLength   = 10000
+)
+
+// minActorBirthDate keeps typos like 0195-04-12 out of the birth dates.
+var minActorBirthDate = time.Date(1850, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ActorExternalIds are the databases an actor can be linked to, with the
+// format of their ids.
+var ActorExternalIds = map[string]*regexp.Regexp{
+	"imdb":     regexp.MustCompile(`^nm[0-9]{7,8}$`),
+	"tmdb":     regexp.MustCompile(`^[0-9]{1,10}$`),
+	"wikidata": regexp.MustCompile(`^Q[0-9]{1,10}$`),
+}
+
 type ActorPrimarKey struct {
 	Id string `json:"actor_id"`
 }
 
+// CreateActor is an actor and their profile. DisplayName is how the actor is
+// credited, first_name and last_name joined when empty. ExternalIds maps
+// the databases in ActorExternalIds to the ids of the actor there.
 type CreateActor struct {
-	First_name string `json:"first_name"`
-	Last_name  string `json:"last_name"`
+	First_name  string            `json:"first_name"`
+	Last_name   string            `json:"last_name"`
+	DisplayName string            `json:"display_name"`
+	Biography   string            `json:"biography"`
+	BirthDate   string            `json:"birth_date" example:"1962-07-03"`
+	Birthplace  string            `json:"birthplace"`
+	ExternalIds map[string]string `json:"external_ids" example:"imdb:nm0000129"`
+}
+
+func (a *CreateActor) Validate() error {
+
+	if a.First_name == "" || a.Last_name == "" {
+		return errors.New("required first_name and last_name")
+	}
+
+	if len(a.First_name) > maxActorNameLength || len(a.Last_name) > maxActorNameLength {
+		return errors.New("first_name and last_name must be at most 45 characters")
+	}
+
+	if utf8.RuneCountInString(a.DisplayName) > maxActorDisplayNameLength {
+		return errors.New("display_name must be at most 100 characters")
+	}
+
+	if utf8.RuneCountInString(a.Birthplace) > maxActorBirthplaceLength {
+		return errors.New("birthplace must be at most 100 characters")
+	}
+
+	if utf8.RuneCountInString(a.Biography) > maxActorBiographyLength {
+		return errors.New("biography must be at most 10000 characters")
+	}
+
+	if a.BirthDate != "" {
+		birthDate, err := time.Parse("2006-01-02", a.BirthDate)
+		if err != nil {
+			return errors.New("birth_date must be formatted as YYYY-MM-DD")
+		}
+
+		if birthDate.Before(minActorBirthDate) || birthDate.After(time.Now()) {
+			return errors.New("birth_date must be between 1850-01-01 and today")
+		}
+	}
+
+	for source, id := range a.ExternalIds {
+
+		pattern, ok := ActorExternalIds[source]
+		if !ok {
+			return fmt.Errorf("external_ids must be any of %v", actorExternalIdSources())
+		}
+
+		if !pattern.MatchString(id) {
+			return fmt.Errorf("external_ids.%s must match %s", source, pattern)
+		}
+	}
+
+	return nil
+}
+
+// ApplyDefaults stores missing external ids as an empty object instead of
+// null.
+func (a *CreateActor) ApplyDefaults() {
+
+	if a.ExternalIds == nil {
+		a.ExternalIds = map[string]string{}
+	}
+}
+
+func actorExternalIdSources() []string {
+
+	sources := make([]string, 0, len(ActorExternalIds))
+	for source := range ActorExternalIds {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	return sources
 }
+
 type Actor struct {
-	Id         string `json:"actor_id"`
-	First_name string `json:"first_name"`
-	Last_name  string `json:"last_name"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
+	Id                string            `json:"actor_id"`
+	First_name        string            `json:"first_name"`
+	Last_name         string            `json:"last_name"`
+	DisplayName       string            `json:"display_name"`
+	Biography         string            `json:"biography"`
+	BirthDate         string            `json:"birth_date"`
+	Birthplace        string            `json:"birthplace"`
+	ExternalIds       map[string]string `json:"external_ids"`
+	PhotoUrl          string            `json:"photo_url"`
+	PhotoThumbnailUrl string            `json:"photo_thumbnail_url"`
+	CreatedAt         string            `json:"created_at"`
+	UpdatedAt         string            `json:"updated_at"`
 }
 
 type UpdateActor struct {
-	First_name string `json:"first_name"`
-	Last_name  string `json:"last_name"`
+	First_name  string            `json:"first_name"`
+	Last_name   string            `json:"last_name"`
+	DisplayName string            `json:"display_name"`
+	Biography   string            `json:"biography"`
+	BirthDate   string            `json:"birth_date" example:"1962-07-03"`
+	Birthplace  string            `json:"birthplace"`
+	ExternalIds map[string]string `json:"external_ids" example:"imdb:nm0000129"`
+}
+
+func (a *UpdateActor) Validate() error {
+
+	actor := CreateActor(*a)
+
+	return actor.Validate()
+}
+
+func (a *UpdateActor) ApplyDefaults() {
+
+	actor := CreateActor(*a)
+	actor.ApplyDefaults()
+
+	*a = UpdateActor(actor)
 }
 
+// GetListActorRequest filters are optional, BirthYear 0 does not filter.
 type GetListActorRequest struct {
-	Limit  int32
-	Offset int32
+	Limit     int32
+	Offset    int32
+	BirthYear int32
 }
 
 type GetListActorResponse struct {
 	Count  int32    `json:"count"`
 	Actors []*Actor `json:"actors"`
 }
+
+type SimilarActor struct {
+	Actor
+	Similarity float32 `json:"similarity"`
+}
+
+type SuggestActorResponse struct {
+	Actors []*SimilarActor `json:"actors"`
+}
+
+type ActorDuplicateWarning struct {
+	Message string          `json:"message"`
+	Similar []*SimilarActor `json:"similar"`
+}
+
+type MergeActor struct {
+	SurvivorId string `json:"survivor_id"`
+}
+
+type ActorMerge struct {
+	MergedActorId   string `json:"merged_actor_id"`
+	SurvivorActorId string `json:"survivor_actor_id"`
+	First_name      string `json:"first_name"`
+	Last_name       string `json:"last_name"`
+	MergedBy        string `json:"merged_by"`
+	MergedAt        string `json:"merged_at"`
+}
+
+type MergeActorResponse struct {
+	Survivor *Actor      `json:"survivor"`
+	Merge    *ActorMerge `json:"merge"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import "errors"
+
+type AddressPrimarKey struct {
+	Id string `json:"address_id"`
+}
+
+type CreateAddress struct {
+	Address    string `json:"address"`
+	Address2   string `json:"address2"`
+	District   string `json:"district"`
+	CityId     string `json:"city_id"`
+	PostalCode string `json:"postal_code"`
+	Phone      string `json:"phone"`
+}
+
+func (a *CreateAddress) Validate() error {
+
+	if a.Address == "" {
+		return errors.New("required address")
+	}
+
+	if len(a.Address) > 50 || len(a.Address2) > 50 {
+		return errors.New("address must be at most 50 characters")
+	}
+
+	if a.District == "" {
+		return errors.New("required district")
+	}
+
+	if len(a.District) > 20 {
+		return errors.New("district must be at most 20 characters")
+	}
+
+	if a.CityId == "" {
+		return errors.New("required city_id")
+	}
+
+	if len(a.PostalCode) > 10 {
+		return errors.New("postal_code must be at most 10 characters")
+	}
+
+	if a.Phone == "" {
+		return errors.New("required phone")
+	}
+
+	if len(a.Phone) > 20 {
+		return errors.New("phone must be at most 20 characters")
+	}
+
+	return nil
+}
+
+type Address struct {
+	Id         string `json:"address_id"`
+	Address    string `json:"address"`
+	Address2   string `json:"address2"`
+	District   string `json:"district"`
+	PostalCode string `json:"postal_code"`
+	Phone      string `json:"phone"`
+	City       *City  `json:"city"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
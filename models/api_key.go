@@ -0,0 +1,40 @@
+package models
+
+type ApiKeyPrimarKey struct {
+	Id string `json:"api_key_id"`
+}
+
+type CreateApiKey struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expires_at"`
+	KeyPrefix string   `json:"-"`
+	KeyHash   string   `json:"-"`
+}
+
+type ApiKey struct {
+	Id         string   `json:"api_key_id"`
+	Name       string   `json:"name"`
+	KeyPrefix  string   `json:"key_prefix"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  string   `json:"expires_at"`
+	LastUsedAt string   `json:"last_used_at"`
+	RevokedAt  string   `json:"revoked_at"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+type CreateApiKeyResponse struct {
+	Key    string  `json:"key"`
+	ApiKey *ApiKey `json:"api_key"`
+}
+
+type GetListApiKeyRequest struct {
+	Limit  int32
+	Offset int32
+}
+
+type GetListApiKeyResponse struct {
+	Count   int32     `json:"count"`
+	ApiKeys []*ApiKey `json:"api_keys"`
+}
@@ -0,0 +1,39 @@
+package models
+
+const (
+	BatchModeAllOrNothing = "all_or_nothing"
+	BatchModeBestEffort   = "best_effort"
+)
+
+type BatchResult struct {
+	Index int    `json:"index"`
+	Id    string `json:"id"`
+	Error string `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Mode      string         `json:"mode"`
+	Succeeded int32          `json:"succeeded"`
+	Failed    int32          `json:"failed"`
+	Results   []*BatchResult `json:"results"`
+}
+
+type DeleteManyRequest struct {
+	Mode string   `json:"mode"`
+	Ids  []string `json:"ids"`
+}
+
+type CreateFilmBatch struct {
+	Mode  string        `json:"mode"`
+	Items []*CreateFilm `json:"items"`
+}
+
+type CreateActorBatch struct {
+	Mode  string         `json:"mode"`
+	Items []*CreateActor `json:"items"`
+}
+
+type CreateCategoryBatch struct {
+	Mode  string            `json:"mode"`
+	Items []*CreateCategory `json:"items"`
+}
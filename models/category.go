@@ -1,21 +1,51 @@
 package models
 
+import "errors"
+
+const maxCategoryNameLength = 25
+
 type CategoryPrimarKey struct {
 	Id string `json:"category_id"`
 }
 
+// CreateCategory is a top level category when ParentId is empty and a
+// subcategory of the parent otherwise.
 type CreateCategory struct {
-	Name string `json:"name"`
+	Name     string `json:"name"`
+	ParentId string `json:"parent_id"`
+}
+
+func (c *CreateCategory) Validate() error {
+
+	if c.Name == "" {
+		return errors.New("required name")
+	}
+
+	if len(c.Name) > maxCategoryNameLength {
+		return errors.New("name must be at most 25 characters")
+	}
+
+	return nil
 }
+
 type Category struct {
 	Id        string `json:"category_id"`
 	Name      string `json:"name"`
+	ParentId  string `json:"parent_id"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
 
 type UpdateCategory struct {
-	Name string `json:"name"`
+	Name     string `json:"name"`
+	ParentId string `json:"parent_id"`
+}
+
+func (c *UpdateCategory) Validate() error {
+
+	category := CreateCategory(*c)
+
+	return category.Validate()
 }
 
 type GetListCategoryRequest struct {
@@ -27,3 +57,26 @@ type GetListCategoryResponse struct {
 	Count     int32       `json:"count"`
 	Categorys []*Category `json:"categorys"`
 }
+
+// CategoryNode is a category with its subcategories, nested all the way down.
+type CategoryNode struct {
+	Category
+	Children []*CategoryNode `json:"children"`
+}
+
+type GetCategoryTreeResponse struct {
+	Count      int32           `json:"count"`
+	Categories []*CategoryNode `json:"categories"`
+}
+
+// CategoryDescendant is a subcategory at Depth levels below the category it
+// was looked up from, 1 for its children.
+type CategoryDescendant struct {
+	Category
+	Depth int32 `json:"depth"`
+}
+
+type GetCategoryDescendantsResponse struct {
+	Count      int32                 `json:"count"`
+	Categories []*CategoryDescendant `json:"categories"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "errors"
+
+const maxCityNameLength = 50
+
+type CityPrimarKey struct {
+	Id string `json:"city_id"`
+}
+
+type CreateCity struct {
+	Name      string `json:"name"`
+	CountryId string `json:"country_id"`
+}
+
+func (c *CreateCity) Validate() error {
+
+	if c.Name == "" {
+		return errors.New("required name")
+	}
+
+	if len(c.Name) > maxCityNameLength {
+		return errors.New("name must be at most 50 characters")
+	}
+
+	if c.CountryId == "" {
+		return errors.New("required country_id")
+	}
+
+	return nil
+}
+
+type City struct {
+	Id        string   `json:"city_id"`
+	Name      string   `json:"name"`
+	Country   *Country `json:"country"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type UpdateCity struct {
+	Name      string `json:"name"`
+	CountryId string `json:"country_id"`
+}
+
+func (c *UpdateCity) Validate() error {
+
+	city := CreateCity(*c)
+
+	return city.Validate()
+}
+
+type GetListCityRequest struct {
+	Limit     int32
+	Offset    int32
+	CountryId string
+}
+
+type GetListCityResponse struct {
+	Count  int32   `json:"count"`
+	Cities []*City `json:"cities"`
+}
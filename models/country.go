@@ -0,0 +1,51 @@
+package models
+
+import "errors"
+
+const maxCountryNameLength = 50
+
+type CountryPrimarKey struct {
+	Id string `json:"country_id"`
+}
+
+type CreateCountry struct {
+	Name string `json:"name"`
+}
+
+func (c *CreateCountry) Validate() error {
+
+	if c.Name == "" {
+		return errors.New("required name")
+	}
+
+	if len(c.Name) > maxCountryNameLength {
+		return errors.New("name must be at most 50 characters")
+	}
+
+	return nil
+}
+
+type Country struct {
+	Id        string `json:"country_id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type UpdateCountry struct {
+	Name string `json:"name"`
+}
+
+func (c *UpdateCountry) Validate() error {
+	return (&CreateCountry{Name: c.Name}).Validate()
+}
+
+type GetListCountryRequest struct {
+	Limit  int32
+	Offset int32
+}
+
+type GetListCountryResponse struct {
+	Count     int32      `json:"count"`
+	Countries []*Country `json:"countries"`
+}
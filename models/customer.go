@@ -0,0 +1,94 @@
+package models
+
+import (
+	"errors"
+	"net/mail"
+)
+
+type CustomerPrimarKey struct {
+	Id string `json:"customer_id"`
+}
+
+type CreateCustomer struct {
+	StoreId   string        `json:"store_id"`
+	FirstName string        `json:"first_name"`
+	LastName  string        `json:"last_name"`
+	Email     string        `json:"email"`
+	Active    *bool         `json:"active"`
+	Address   CreateAddress `json:"address"`
+	AddressId string        `json:"-"`
+}
+
+func (c *CreateCustomer) Validate() error {
+
+	if c.StoreId == "" {
+		return errors.New("required store_id")
+	}
+
+	if c.FirstName == "" || c.LastName == "" {
+		return errors.New("required first_name and last_name")
+	}
+
+	if len(c.FirstName) > 45 || len(c.LastName) > 45 {
+		return errors.New("first_name and last_name must be at most 45 characters")
+	}
+
+	if len(c.Email) > 50 {
+		return errors.New("email must be at most 50 characters")
+	}
+
+	if _, err := mail.ParseAddress(c.Email); err != nil {
+		return errors.New("invalid email")
+	}
+
+	return c.Address.Validate()
+}
+
+type Customer struct {
+	Id        string   `json:"customer_id"`
+	StoreId   string   `json:"store_id"`
+	FirstName string   `json:"first_name"`
+	LastName  string   `json:"last_name"`
+	Email     string   `json:"email"`
+	Active    bool     `json:"active"`
+	Address   *Address `json:"address"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// UpdateCustomer replaces the customer and its address. Active is kept as is
+// when it is left out.
+type UpdateCustomer struct {
+	StoreId   string        `json:"store_id"`
+	FirstName string        `json:"first_name"`
+	LastName  string        `json:"last_name"`
+	Email     string        `json:"email"`
+	Active    *bool         `json:"active"`
+	Address   CreateAddress `json:"address"`
+}
+
+func (c *UpdateCustomer) Validate() error {
+
+	customer := CreateCustomer{
+		StoreId:   c.StoreId,
+		FirstName: c.FirstName,
+		LastName:  c.LastName,
+		Email:     c.Email,
+		Address:   c.Address,
+	}
+
+	return customer.Validate()
+}
+
+type GetListCustomerRequest struct {
+	Limit   int32
+	Offset  int32
+	StoreId string
+	CityId  string
+	Active  *bool
+}
+
+type GetListCustomerResponse struct {
+	Count     int32       `json:"count"`
+	Customers []*Customer `json:"customers"`
+}
@@ -1,38 +1,239 @@
 package models
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	RatingG    = "G"
+	RatingPG   = "PG"
+	RatingPG13 = "PG-13"
+	RatingR    = "R"
+	RatingNC17 = "NC-17"
+)
+
+const (
+	FeatureTrailers        = "Trailers"
+	FeatureCommentaries    = "Commentaries"
+	FeatureDeletedScenes   = "Deleted Scenes"
+	FeatureBehindTheScenes = "Behind the Scenes"
+)
+
+const DefaultRentalDuration = 3
+
+var (
+	Ratings         = []string{RatingG, RatingPG, RatingPG13, RatingR, RatingNC17}
+	SpecialFeatures = []string{FeatureTrailers, FeatureCommentaries, FeatureDeletedScenes, FeatureBehindTheScenes}
+
+	DefaultRentalRate      = decimal.RequireFromString("4.99")
+	DefaultReplacementCost = decimal.RequireFromString("19.99")
+
+	// maxRentalRate and maxReplacementCost are the exclusive bounds of the
+	// NUMERIC(4,2) and NUMERIC(5,2) columns.
+	maxRentalRate      = decimal.New(100, 0)
+	maxReplacementCost = decimal.New(1000, 0)
+)
+
 type FilmPrimarKey struct {
 	Id string `json:"film_id"`
 }
 
 type CreateFilm struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	ReleaseYear string `json:"release_year"`
-	Duration    int32  `json:"duration"`
+	Title              string           `json:"title"`
+	Description        string           `json:"description"`
+	ReleaseYear        string           `json:"release_year"`
+	Duration           int32            `json:"duration"`
+	LanguageId         string           `json:"language_id"`
+	OriginalLanguageId string           `json:"original_language_id"`
+	Rating             string           `json:"rating" example:"PG-13"`
+	RentalDuration     int32            `json:"rental_duration" example:"3"`
+	RentalRate         *decimal.Decimal `json:"rental_rate" swaggertype:"string" example:"4.99"`
+	ReplacementCost    *decimal.Decimal `json:"replacement_cost" swaggertype:"string" example:"19.99"`
+	SpecialFeatures    []string         `json:"special_features"`
+}
+
+func (f *CreateFilm) Validate() error {
+
+	if f.Title == "" {
+		return errors.New("required title")
+	}
+
+	if f.ReleaseYear == "" {
+		return errors.New("required release_year")
+	}
+
+	if _, err := time.Parse("2006-01-02", f.ReleaseYear); err != nil {
+		return errors.New("release_year must be formatted as YYYY-MM-DD")
+	}
+
+	if f.Duration < 0 {
+		return errors.New("duration must not be negative")
+	}
+
+	if f.Rating != "" && !IsRating(f.Rating) {
+		return fmt.Errorf("rating must be one of %v", Ratings)
+	}
+
+	if f.RentalDuration < 0 {
+		return errors.New("rental_duration must not be negative")
+	}
+
+	if f.RentalRate != nil && (f.RentalRate.IsNegative() || f.RentalRate.GreaterThanOrEqual(maxRentalRate)) {
+		return errors.New("rental_rate must be between 0 and 99.99")
+	}
+
+	if f.ReplacementCost != nil && (f.ReplacementCost.IsNegative() || f.ReplacementCost.GreaterThanOrEqual(maxReplacementCost)) {
+		return errors.New("replacement_cost must be between 0 and 999.99")
+	}
+
+	for _, feature := range f.SpecialFeatures {
+		if !isSpecialFeature(feature) {
+			return fmt.Errorf("special_features must be any of %v", SpecialFeatures)
+		}
+	}
+
+	return nil
+}
+
+// ApplyDefaults fills the rental fields left out by the client with the
+// catalog defaults.
+func (f *CreateFilm) ApplyDefaults() {
+
+	if f.Rating == "" {
+		f.Rating = RatingG
+	}
+
+	if f.RentalDuration == 0 {
+		f.RentalDuration = DefaultRentalDuration
+	}
+
+	if f.RentalRate == nil {
+		f.RentalRate = &DefaultRentalRate
+	}
+
+	if f.ReplacementCost == nil {
+		f.ReplacementCost = &DefaultReplacementCost
+	}
+
+	if f.SpecialFeatures == nil {
+		f.SpecialFeatures = []string{}
+	}
 }
+
+func IsRating(rating string) bool {
+
+	for _, r := range Ratings {
+		if r == rating {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSpecialFeature(feature string) bool {
+
+	for _, f := range SpecialFeatures {
+		if f == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Film struct {
-	Id          string `json:"film_id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	ReleaseYear string `json:"release_year"`
-	Duration    int32  `json:"duration"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	Id                 string           `json:"film_id"`
+	Title              string           `json:"title"`
+	Description        string           `json:"description"`
+	ReleaseYear        string           `json:"release_year"`
+	Duration           int32            `json:"duration"`
+	Language           *Language        `json:"language"`
+	OriginalLanguage   *Language        `json:"original_language"`
+	Rating             string           `json:"rating"`
+	RentalDuration     int32            `json:"rental_duration"`
+	RentalRate         decimal.Decimal  `json:"rental_rate" swaggertype:"string" example:"4.99"`
+	ReplacementCost    decimal.Decimal  `json:"replacement_cost" swaggertype:"string" example:"19.99"`
+	SpecialFeatures    []string         `json:"special_features"`
+	AverageRating      *decimal.Decimal `json:"average_rating" swaggertype:"string" example:"4.25"`
+	RatingCount        int32            `json:"rating_count"`
+	PosterUrl          string           `json:"poster_url"`
+	PosterThumbnailUrl string           `json:"poster_thumbnail_url"`
+	TrailerUrl         string           `json:"trailer_url"`
+	CreatedAt          string           `json:"created_at"`
+	UpdatedAt          string           `json:"updated_at"`
 }
 
 type UpdateFilm struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	ReleaseYear string `json:"release_year"`
-	Duration    int32  `json:"duration"`
+	Title              string           `json:"title"`
+	Description        string           `json:"description"`
+	ReleaseYear        string           `json:"release_year"`
+	Duration           int32            `json:"duration"`
+	LanguageId         string           `json:"language_id"`
+	OriginalLanguageId string           `json:"original_language_id"`
+	Rating             string           `json:"rating" example:"PG-13"`
+	RentalDuration     int32            `json:"rental_duration" example:"3"`
+	RentalRate         *decimal.Decimal `json:"rental_rate" swaggertype:"string" example:"4.99"`
+	ReplacementCost    *decimal.Decimal `json:"replacement_cost" swaggertype:"string" example:"19.99"`
+	SpecialFeatures    []string         `json:"special_features"`
+}
+
+func (f *UpdateFilm) Validate() error {
+
+	film := CreateFilm(*f)
+
+	return film.Validate()
 }
 
+func (f *UpdateFilm) ApplyDefaults() {
+
+	film := CreateFilm(*f)
+	film.ApplyDefaults()
+
+	*f = UpdateFilm(film)
+}
+
+// GetListFilmRequest filters are optional, the zero value of each one does not
+// filter at all.
 type GetListFilmRequest struct {
-	Limit  int32
-	Offset int32
+	Limit              int32
+	Offset             int32
+	Ratings            []string
+	MinRentalRate      *decimal.Decimal
+	MaxRentalRate      *decimal.Decimal
+	MinReplacementCost *decimal.Decimal
+	MaxReplacementCost *decimal.Decimal
+	// CategoryId keeps the films of the category, and of its subcategories
+	// too with IncludeDescendants.
+	CategoryId         string
+	IncludeDescendants bool
 }
 
 type GetListFilmResponse struct {
 	Count int32   `json:"count"`
 	Films []*Film `json:"films"`
 }
+
+type FilmActorPrimarKey struct {
+	FilmId  string `json:"film_id"`
+	ActorId string `json:"actor_id"`
+}
+
+type GetFilmActorsResponse struct {
+	Count  int32    `json:"count"`
+	Actors []*Actor `json:"actors"`
+}
+
+type FilmCategoryPrimarKey struct {
+	FilmId     string `json:"film_id"`
+	CategoryId string `json:"category_id"`
+}
+
+type GetFilmCategoriesResponse struct {
+	Count      int32       `json:"count"`
+	Categories []*Category `json:"categories"`
+}
@@ -0,0 +1,17 @@
+package models
+
+type IdempotencyKeyPrimarKey struct {
+	Scope string `json:"scope"`
+	Key   string `json:"idempotency_key"`
+}
+
+type IdempotencyKey struct {
+	Scope        string `json:"scope"`
+	Key          string `json:"idempotency_key"`
+	RequestHash  string `json:"request_hash"`
+	StatusCode   int32  `json:"status_code"`
+	ResponseBody []byte `json:"response_body"`
+	Completed    bool   `json:"completed"`
+	CreatedAt    string `json:"created_at"`
+	ExpiresAt    string `json:"expires_at"`
+}
@@ -0,0 +1,8 @@
+package models
+
+type Identity struct {
+	Subject     string   `json:"subject"`
+	Kind        string   `json:"kind"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
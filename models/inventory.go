@@ -0,0 +1,96 @@
+package models
+
+import "errors"
+
+const (
+	InventoryStatusAvailable = "available"
+	InventoryStatusRented    = "rented"
+)
+
+type InventoryPrimarKey struct {
+	Id string `json:"inventory_id"`
+}
+
+type CreateInventory struct {
+	FilmId  string `json:"film_id"`
+	StoreId string `json:"store_id"`
+}
+
+func (i *CreateInventory) Validate() error {
+
+	if i.FilmId == "" {
+		return errors.New("required film_id")
+	}
+
+	if i.StoreId == "" {
+		return errors.New("required store_id")
+	}
+
+	return nil
+}
+
+type Inventory struct {
+	Id        string `json:"inventory_id"`
+	FilmId    string `json:"film_id"`
+	StoreId   string `json:"store_id"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// UpdateInventory moves a copy to another store. The status is owned by the
+// rental flow and cannot be changed directly.
+type UpdateInventory struct {
+	StoreId string `json:"store_id"`
+}
+
+type GetListInventoryRequest struct {
+	Limit   int32
+	Offset  int32
+	FilmId  string
+	StoreId string
+	Status  string
+}
+
+type GetListInventoryResponse struct {
+	Count       int32        `json:"count"`
+	Inventories []*Inventory `json:"inventories"`
+}
+
+// InventoryCount splits the copies of a film into available and rented ones.
+type InventoryCount struct {
+	Total     int32 `json:"total"`
+	Available int32 `json:"available"`
+	Rented    int32 `json:"rented"`
+}
+
+type FilmStoreInventory struct {
+	StoreId   string `json:"store_id"`
+	StoreName string `json:"store_name"`
+	InventoryCount
+}
+
+type GetFilmInventoryResponse struct {
+	FilmId string `json:"film_id"`
+	InventoryCount
+	Stores []*FilmStoreInventory `json:"stores"`
+}
+
+type StoreFilmInventory struct {
+	FilmId string `json:"film_id"`
+	Title  string `json:"title"`
+	InventoryCount
+}
+
+type GetStoreInventoryRequest struct {
+	Limit         int32
+	Offset        int32
+	StoreId       string
+	AvailableOnly bool
+}
+
+type GetStoreInventoryResponse struct {
+	StoreId string                `json:"store_id"`
+	Count   int32                 `json:"count"`
+	Films   []*StoreFilmInventory `json:"films"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "errors"
+
+const maxLanguageNameLength = 20
+
+type LanguagePrimarKey struct {
+	Id string `json:"language_id"`
+}
+
+type CreateLanguage struct {
+	Name string `json:"name"`
+}
+
+func (l *CreateLanguage) Validate() error {
+
+	if l.Name == "" {
+		return errors.New("required name")
+	}
+
+	if len(l.Name) > maxLanguageNameLength {
+		return errors.New("name must be at most 20 characters")
+	}
+
+	return nil
+}
+
+type Language struct {
+	Id        string `json:"language_id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type UpdateLanguage struct {
+	Name string `json:"name"`
+}
+
+func (l *UpdateLanguage) Validate() error {
+	return (&CreateLanguage{Name: l.Name}).Validate()
+}
+
+type GetListLanguageRequest struct {
+	Limit  int32
+	Offset int32
+}
+
+type GetListLanguageResponse struct {
+	Count     int32       `json:"count"`
+	Languages []*Language `json:"languages"`
+}
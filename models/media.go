@@ -0,0 +1,9 @@
+package models
+
+// MediaAsset is an uploaded file in the blob store. ThumbnailUrl is only set
+// for images.
+type MediaAsset struct {
+	Key          string
+	Url          string
+	ThumbnailUrl string
+}
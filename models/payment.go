@@ -0,0 +1,96 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	PaymentKindPayment = "payment"
+	PaymentKindRefund  = "refund"
+)
+
+type PaymentPrimarKey struct {
+	Id string `json:"payment_id"`
+}
+
+// CreatePayment pays for a rental. The outstanding amount of the rental is
+// paid when Amount is left out.
+type CreatePayment struct {
+	RentalId string           `json:"rental_id"`
+	Amount   *decimal.Decimal `json:"amount" swaggertype:"string" example:"4.99"`
+	StaffId  string           `json:"-"`
+}
+
+func (p *CreatePayment) Validate() error {
+
+	if p.RentalId == "" {
+		return errors.New("required rental_id")
+	}
+
+	return validateAmount(p.Amount)
+}
+
+// RefundPayment refunds a payment. The refundable rest of the payment is
+// refunded when Amount is left out.
+type RefundPayment struct {
+	Amount  *decimal.Decimal `json:"amount" swaggertype:"string" example:"4.99"`
+	StaffId string           `json:"-"`
+}
+
+func (p *RefundPayment) Validate() error {
+	return validateAmount(p.Amount)
+}
+
+func validateAmount(amount *decimal.Decimal) error {
+
+	if amount == nil {
+		return nil
+	}
+
+	if !amount.IsPositive() {
+		return errors.New("amount must be positive")
+	}
+
+	if !amount.Equal(amount.Round(2)) {
+		return errors.New("amount must have at most 2 decimal places")
+	}
+
+	return nil
+}
+
+type Payment struct {
+	Id          string          `json:"payment_id"`
+	CustomerId  string          `json:"customer_id"`
+	RentalId    string          `json:"rental_id"`
+	Kind        string          `json:"kind"`
+	Amount      decimal.Decimal `json:"amount" swaggertype:"string" example:"4.99"`
+	RefundOf    string          `json:"refund_of"`
+	StaffId     string          `json:"staff_id"`
+	PaymentDate string          `json:"payment_date"`
+	CreatedAt   string          `json:"created_at"`
+}
+
+type GetListPaymentRequest struct {
+	Limit      int32
+	Offset     int32
+	CustomerId string
+	RentalId   string
+	StaffId    string
+}
+
+type GetListPaymentResponse struct {
+	Count    int32      `json:"count"`
+	Payments []*Payment `json:"payments"`
+}
+
+// CustomerBalance is what the customer was charged minus what they paid,
+// refunds count as unpaid again. A positive balance is owed by the customer.
+type CustomerBalance struct {
+	CustomerId string          `json:"customer_id"`
+	Charged    decimal.Decimal `json:"charged" swaggertype:"string" example:"9.98"`
+	Paid       decimal.Decimal `json:"paid" swaggertype:"string" example:"4.99"`
+	Refunded   decimal.Decimal `json:"refunded" swaggertype:"string" example:"0.00"`
+	Balance    decimal.Decimal `json:"balance" swaggertype:"string" example:"4.99"`
+}
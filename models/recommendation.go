@@ -0,0 +1,50 @@
+package models
+
+// SimilarityWeights is what one shared actor, one shared category and one
+// customer that rented both films add to the score of a pair of films.
+type SimilarityWeights struct {
+	Actor    float64
+	Category float64
+	CoRenter float64
+}
+
+type GetSimilarFilmsRequest struct {
+	FilmId  string
+	Limit   int32
+	Offset  int32
+	Weights SimilarityWeights
+}
+
+type SimilarFilm struct {
+	Film
+	Score            float64 `json:"score"`
+	SharedActors     int32   `json:"shared_actors"`
+	SharedCategories int32   `json:"shared_categories"`
+	CoRenters        int32   `json:"co_renters"`
+}
+
+type GetSimilarFilmsResponse struct {
+	Count       int32          `json:"count"`
+	RefreshedAt string         `json:"refreshed_at"`
+	Films       []*SimilarFilm `json:"films"`
+}
+
+// GetRecommendationsRequest recommends films similar to the ones the
+// customer rented before, leaving out those they already rented.
+type GetRecommendationsRequest struct {
+	CustomerId string
+	Limit      int32
+	Offset     int32
+	Weights    SimilarityWeights
+}
+
+type RecommendedFilm struct {
+	Film
+	Score float64 `json:"score"`
+}
+
+type GetRecommendationsResponse struct {
+	Count       int32              `json:"count"`
+	RefreshedAt string             `json:"refreshed_at"`
+	Films       []*RecommendedFilm `json:"films"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	RentalStatusOpen     = "open"
+	RentalStatusOverdue  = "overdue"
+	RentalStatusReturned = "returned"
+)
+
+type RentalPrimarKey struct {
+	Id string `json:"rental_id"`
+}
+
+type CreateRental struct {
+	InventoryId string `json:"inventory_id"`
+	CustomerId  string `json:"customer_id"`
+	StaffId     string `json:"-"`
+}
+
+func (r *CreateRental) Validate() error {
+
+	if r.InventoryId == "" {
+		return errors.New("required inventory_id")
+	}
+
+	if r.CustomerId == "" {
+		return errors.New("required customer_id")
+	}
+
+	return nil
+}
+
+type Rental struct {
+	Id            string          `json:"rental_id"`
+	InventoryId   string          `json:"inventory_id"`
+	FilmId        string          `json:"film_id"`
+	Title         string          `json:"title"`
+	StoreId       string          `json:"store_id"`
+	CustomerId    string          `json:"customer_id"`
+	StaffId       string          `json:"staff_id"`
+	ReturnStaffId string          `json:"return_staff_id"`
+	Status        string          `json:"status"`
+	RentalDate    string          `json:"rental_date"`
+	DueDate       string          `json:"due_date"`
+	ReturnDate    string          `json:"return_date"`
+	RentalFee     decimal.Decimal `json:"rental_fee" swaggertype:"string" example:"4.99"`
+	LateFee       decimal.Decimal `json:"late_fee" swaggertype:"string" example:"0.00"`
+	CreatedAt     string          `json:"created_at"`
+	UpdatedAt     string          `json:"updated_at"`
+}
+
+type GetListRentalRequest struct {
+	Limit      int32
+	Offset     int32
+	CustomerId string
+	StaffId    string
+	Status     string
+}
+
+type GetListRentalResponse struct {
+	Count   int32     `json:"count"`
+	Rentals []*Rental `json:"rentals"`
+}
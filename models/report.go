@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ReportGroupByStore = "store"
+	ReportGroupByStaff = "staff"
+)
+
+// ReportPeriod limits a report to [From, To). A zero bound is open.
+type ReportPeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+type GetTopFilmsRequest struct {
+	ReportPeriod
+	Limit   int32
+	Offset  int32
+	StoreId string
+}
+
+type TopFilm struct {
+	FilmId  string          `json:"film_id"`
+	Title   string          `json:"title"`
+	Rentals int32           `json:"rentals"`
+	Revenue decimal.Decimal `json:"revenue" swaggertype:"string" example:"24.95"`
+}
+
+type GetTopFilmsResponse struct {
+	Films []*TopFilm `json:"films"`
+}
+
+type CategoryRevenue struct {
+	CategoryId string          `json:"category_id"`
+	Name       string          `json:"name"`
+	Films      int32           `json:"films"`
+	Rentals    int32           `json:"rentals"`
+	Revenue    decimal.Decimal `json:"revenue" swaggertype:"string" example:"124.75"`
+	Share      decimal.Decimal `json:"share" swaggertype:"string" example:"0.1250"`
+}
+
+// GetCategoryRevenueResponse is read from a materialized view, RefreshedAt
+// tells how fresh it is.
+type GetCategoryRevenueResponse struct {
+	RefreshedAt string             `json:"refreshed_at"`
+	Categories  []*CategoryRevenue `json:"categories"`
+}
+
+type GetActorPopularityRequest struct {
+	Limit  int32
+	Offset int32
+}
+
+type ActorPopularity struct {
+	ActorId   string          `json:"actor_id"`
+	FirstName string          `json:"first_name"`
+	LastName  string          `json:"last_name"`
+	Films     int32           `json:"films"`
+	Rentals   int32           `json:"rentals"`
+	Revenue   decimal.Decimal `json:"revenue" swaggertype:"string" example:"74.85"`
+}
+
+// GetActorPopularityResponse is read from a materialized view, RefreshedAt
+// tells how fresh it is.
+type GetActorPopularityResponse struct {
+	Count       int32              `json:"count"`
+	RefreshedAt string             `json:"refreshed_at"`
+	Actors      []*ActorPopularity `json:"actors"`
+}
+
+type GetStoreRevenueRequest struct {
+	ReportPeriod
+	GroupBy string
+}
+
+// StoreRevenue sums the payments taken in the period. Staff fields are only
+// set when the report is grouped by staff.
+type StoreRevenue struct {
+	StoreId   string          `json:"store_id"`
+	StoreName string          `json:"store_name"`
+	StaffId   string          `json:"staff_id,omitempty"`
+	StaffName string          `json:"staff_name,omitempty"`
+	Payments  int32           `json:"payments"`
+	Revenue   decimal.Decimal `json:"revenue" swaggertype:"string" example:"49.90"`
+	Refunded  decimal.Decimal `json:"refunded" swaggertype:"string" example:"4.99"`
+	Net       decimal.Decimal `json:"net" swaggertype:"string" example:"44.91"`
+}
+
+type GetStoreRevenueResponse struct {
+	GroupBy string          `json:"group_by"`
+	Stores  []*StoreRevenue `json:"stores"`
+}
@@ -0,0 +1,91 @@
+package models
+
+import "errors"
+
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+
+	ReviewSortNewest  = "newest"
+	ReviewSortHelpful = "helpful"
+
+	maxReviewBodyLength = 2000
+)
+
+type ReviewPrimarKey struct {
+	Id string `json:"review_id"`
+}
+
+// CreateReview rates a film. FilmId and UserId are taken from the path and
+// the authenticated user.
+type CreateReview struct {
+	Rating int32  `json:"rating" example:"4"`
+	Body   string `json:"body"`
+	FilmId string `json:"-"`
+	UserId string `json:"-"`
+}
+
+func (r *CreateReview) Validate() error {
+
+	if r.Rating < 1 || r.Rating > 5 {
+		return errors.New("rating must be between 1 and 5")
+	}
+
+	if len(r.Body) > maxReviewBodyLength {
+		return errors.New("body must be at most 2000 characters")
+	}
+
+	return nil
+}
+
+// Review keeps its rating when the author's account is deleted, UserId is
+// empty from then on.
+type Review struct {
+	Id           string `json:"review_id"`
+	FilmId       string `json:"film_id"`
+	UserId       string `json:"user_id"`
+	Rating       int32  `json:"rating"`
+	Body         string `json:"body"`
+	Status       string `json:"status"`
+	HelpfulCount int32  `json:"helpful_count"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type UpdateReviewStatus struct {
+	Status string `json:"status" example:"approved"`
+}
+
+func (r *UpdateReviewStatus) Validate() error {
+
+	if !IsReviewStatus(r.Status) {
+		return errors.New("status must be pending, approved or rejected")
+	}
+
+	return nil
+}
+
+func IsReviewStatus(status string) bool {
+	return status == ReviewStatusPending || status == ReviewStatusApproved || status == ReviewStatusRejected
+}
+
+// ReviewVote marks a review as helpful for the user, or takes that back.
+type ReviewVote struct {
+	Helpful  bool   `json:"helpful"`
+	ReviewId string `json:"-"`
+	UserId   string `json:"-"`
+}
+
+type GetListReviewRequest struct {
+	Limit  int32
+	Offset int32
+	FilmId string
+	Status string
+	Sort   string
+}
+
+type GetListReviewResponse struct {
+	Count   int32     `json:"count"`
+	Reviews []*Review `json:"reviews"`
+}
@@ -0,0 +1,35 @@
+package models
+
+const (
+	SearchTypeFilm     = "film"
+	SearchTypeActor    = "actor"
+	SearchTypeCategory = "category"
+)
+
+type SearchRequest struct {
+	Query  string
+	Types  []string
+	Limit  int32
+	Offset int32
+}
+
+type SearchHit struct {
+	Type     string  `json:"type"`
+	Id       string  `json:"id"`
+	Title    string  `json:"title"`
+	Headline string  `json:"headline"`
+	Snippet  string  `json:"snippet"`
+	Rank     float32 `json:"rank"`
+}
+
+type SearchGroup struct {
+	Count int32        `json:"count"`
+	Hits  []*SearchHit `json:"hits"`
+}
+
+type SearchResponse struct {
+	Query      string       `json:"query"`
+	Films      *SearchGroup `json:"films,omitempty"`
+	Actors     *SearchGroup `json:"actors,omitempty"`
+	Categories *SearchGroup `json:"categories,omitempty"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"net/mail"
+)
+
+type StaffPrimarKey struct {
+	Id string `json:"staff_id"`
+}
+
+// CreateStaff adds an employee to a store. UserId links the staff member to
+// the login account used to authenticate rental and payment writes.
+type CreateStaff struct {
+	StoreId   string `json:"store_id"`
+	UserId    string `json:"user_id"`
+	ManagerId string `json:"manager_id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Active    *bool  `json:"active"`
+}
+
+func (s *CreateStaff) Validate() error {
+
+	if s.StoreId == "" {
+		return errors.New("required store_id")
+	}
+
+	if s.FirstName == "" || s.LastName == "" {
+		return errors.New("required first_name and last_name")
+	}
+
+	if len(s.FirstName) > 45 || len(s.LastName) > 45 {
+		return errors.New("first_name and last_name must be at most 45 characters")
+	}
+
+	if len(s.Email) > 50 {
+		return errors.New("email must be at most 50 characters")
+	}
+
+	if _, err := mail.ParseAddress(s.Email); err != nil {
+		return errors.New("invalid email")
+	}
+
+	return nil
+}
+
+type Staff struct {
+	Id        string `json:"staff_id"`
+	StoreId   string `json:"store_id"`
+	UserId    string `json:"user_id"`
+	ManagerId string `json:"manager_id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Active    bool   `json:"active"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// UpdateStaff replaces the staff member. Active is kept as is when it is
+// left out.
+type UpdateStaff struct {
+	StoreId   string `json:"store_id"`
+	UserId    string `json:"user_id"`
+	ManagerId string `json:"manager_id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Active    *bool  `json:"active"`
+}
+
+func (s *UpdateStaff) Validate() error {
+
+	staff := CreateStaff{
+		StoreId:   s.StoreId,
+		FirstName: s.FirstName,
+		LastName:  s.LastName,
+		Email:     s.Email,
+	}
+
+	return staff.Validate()
+}
+
+type GetListStaffRequest struct {
+	Limit     int32
+	Offset    int32
+	StoreId   string
+	ManagerId string
+	Active    *bool
+}
+
+type GetListStaffResponse struct {
+	Count int32    `json:"count"`
+	Staff []*Staff `json:"staff"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "errors"
+
+const maxStoreNameLength = 50
+
+type StorePrimarKey struct {
+	Id string `json:"store_id"`
+}
+
+type CreateStore struct {
+	Name string `json:"name"`
+}
+
+func (s *CreateStore) Validate() error {
+
+	if s.Name == "" {
+		return errors.New("required name")
+	}
+
+	if len(s.Name) > maxStoreNameLength {
+		return errors.New("name must be at most 50 characters")
+	}
+
+	return nil
+}
+
+type Store struct {
+	Id        string `json:"store_id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type UpdateStore struct {
+	Name string `json:"name"`
+}
+
+func (s *UpdateStore) Validate() error {
+	return (&CreateStore{Name: s.Name}).Validate()
+}
+
+type GetListStoreRequest struct {
+	Limit  int32
+	Offset int32
+}
+
+type GetListStoreResponse struct {
+	Count  int32    `json:"count"`
+	Stores []*Store `json:"stores"`
+}
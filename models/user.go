@@ -0,0 +1,51 @@
+package models
+
+type UserPrimarKey struct {
+	Id string `json:"user_id"`
+}
+
+type CreateUser struct {
+	Email        string   `json:"email"`
+	Password     string   `json:"password"`
+	PasswordHash string   `json:"-"`
+	Roles        []string `json:"-"`
+}
+
+type User struct {
+	Id           string   `json:"user_id"`
+	Email        string   `json:"email"`
+	Roles        []string `json:"roles"`
+	PasswordHash string   `json:"-"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+type AuthResponse struct {
+	User   *User          `json:"user"`
+	Tokens *TokenResponse `json:"tokens"`
+}
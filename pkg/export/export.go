@@ -0,0 +1,95 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+// Writer streams exported rows. NDJSON encodes the item itself, the tabular
+// formats write the values in the order of the columns.
+type Writer interface {
+	Write(item interface{}, values []string) error
+	Close() error
+}
+
+func NewWriter(w io.Writer, format string, sheet string, columns []string) (Writer, error) {
+
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		return &csvWriter{writer: writer}, writer.Write(columns)
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, sheet, columns)
+	}
+
+	return nil, fmt.Errorf("unsupported format %q, expected %s, %s or %s", format, FormatCSV, FormatNDJSON, FormatXLSX)
+}
+
+func IsFormat(format string) bool {
+	return format == FormatCSV || format == FormatNDJSON || format == FormatXLSX
+}
+
+func ContentType(format string) string {
+
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "application/octet-stream"
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	rows   int
+}
+
+// csv.Writer buffers, flush now and then so rows reach the client while
+// the query is still running.
+const csvFlushEvery = 500
+
+func (w *csvWriter) Write(item interface{}, values []string) error {
+
+	err := w.writer.Write(values)
+	if err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%csvFlushEvery == 0 {
+		w.writer.Flush()
+	}
+
+	return w.writer.Error()
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(item interface{}, values []string) error {
+	return w.encoder.Encode(item)
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,157 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxWriter writes a single sheet workbook with inline strings so that
+// rows can be streamed without keeping a shared string table in memory.
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+}
+
+func newXLSXWriter(w io.Writer, sheet string, columns []string) (*xlsxWriter, error) {
+
+	archive := zip.NewWriter(w)
+
+	var name = sheet
+	if len(name) > 31 {
+		name = name[:31]
+	}
+
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(name))
+
+	files := []struct {
+		name string
+		body string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, escaped.String())},
+	}
+
+	for _, file := range files {
+		part, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.WriteString(part, file.body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	part, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &xlsxWriter{
+		zip:   archive,
+		sheet: bufio.NewWriter(part),
+	}
+
+	_, err = writer.sheet.WriteString(xlsxSheetStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return writer, writer.Write(nil, columns)
+}
+
+func (w *xlsxWriter) Write(item interface{}, values []string) error {
+
+	w.sheet.WriteString("<row>")
+
+	for _, value := range values {
+		if isNumber(value) {
+			w.sheet.WriteString(`<c><v>` + value + `</v></c>`)
+			continue
+		}
+
+		w.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		xml.EscapeText(w.sheet, []byte(value))
+		w.sheet.WriteString(`</t></is></c>`)
+	}
+
+	_, err := w.sheet.WriteString("</row>")
+
+	return err
+}
+
+func (w *xlsxWriter) Close() error {
+
+	_, err := w.sheet.WriteString(xlsxSheetEnd)
+	if err != nil {
+		return err
+	}
+
+	err = w.sheet.Flush()
+	if err != nil {
+		return err
+	}
+
+	return w.zip.Close()
+}
+
+// isNumber accepts plain decimals only, leading zeros are kept as text so
+// codes like "007" survive the round trip.
+func isNumber(value string) bool {
+
+	if value == "" || len(value) > 15 || (len(value) > 1 && value[0] == '0' && value[1] != '.') {
+		return false
+	}
+
+	var dot bool
+	for i, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+		case r == '-' && i == 0 && len(value) > 1:
+		case r == '.' && !dot && i > 0 && i < len(value)-1:
+			dot = true
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,66 @@
+package fee
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const day = 24 * time.Hour
+
+// Policy describes how late returns are charged. PerDay is added for every
+// started day past the due date, the late fee never exceeds the replacement
+// cost of the film.
+type Policy struct {
+	PerDay decimal.Decimal
+}
+
+// Rental is what the fee depends on.
+type Rental struct {
+	RentalRate      decimal.Decimal
+	ReplacementCost decimal.Decimal
+	RentalDuration  int32
+	RentalDate      time.Time
+	ReturnDate      time.Time
+}
+
+type Charge struct {
+	RentalFee decimal.Decimal
+	LateFee   decimal.Decimal
+	DaysLate  int32
+}
+
+func (c Charge) Total() decimal.Decimal {
+	return c.RentalFee.Add(c.LateFee)
+}
+
+// DueDate is the end of the rental period.
+func DueDate(rentalDate time.Time, rentalDuration int32) time.Time {
+	return rentalDate.AddDate(0, 0, int(rentalDuration))
+}
+
+// Calculate charges the rental rate for the rental period and the late fee
+// for the days the copy came back after its due date.
+func (p Policy) Calculate(rental Rental) Charge {
+
+	charge := Charge{
+		RentalFee: rental.RentalRate,
+		LateFee:   decimal.Zero,
+	}
+
+	late := rental.ReturnDate.Sub(DueDate(rental.RentalDate, rental.RentalDuration))
+	if late <= 0 {
+		return charge
+	}
+
+	charge.DaysLate = int32((late + day - 1) / day)
+
+	charge.LateFee = p.PerDay.Mul(decimal.New(int64(charge.DaysLate), 0))
+	if charge.LateFee.GreaterThan(rental.ReplacementCost) {
+		charge.LateFee = rental.ReplacementCost
+	}
+
+	charge.LateFee = charge.LateFee.Round(2)
+
+	return charge
+}
@@ -0,0 +1,91 @@
+package fee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculate(t *testing.T) {
+
+	var (
+		policy = Policy{PerDay: decimal.RequireFromString("1.50")}
+		rented = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+		due    = DueDate(rented, 3)
+	)
+
+	tests := []struct {
+		name     string
+		cost     string
+		returned time.Time
+		late     string
+		daysLate int32
+	}{
+		{"early", "20.00", due.Add(-day), "0", 0},
+		{"on the due date", "20.00", due, "0", 0},
+		{"one second late", "20.00", due.Add(time.Second), "1.50", 1},
+		{"exactly one day late", "20.00", due.Add(day), "1.50", 1},
+		{"started second day", "20.00", due.Add(day + time.Minute), "3.00", 2},
+		{"capped at replacement cost", "9.99", due.Add(30 * day), "9.99", 30},
+		{"at the cap", "6.00", due.Add(4 * day), "6.00", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			charge := policy.Calculate(Rental{
+				RentalRate:      decimal.RequireFromString("2.99"),
+				ReplacementCost: decimal.RequireFromString(tt.cost),
+				RentalDuration:  3,
+				RentalDate:      rented,
+				ReturnDate:      tt.returned,
+			})
+
+			if !charge.RentalFee.Equal(decimal.RequireFromString("2.99")) {
+				t.Errorf("rental fee = %s, want 2.99", charge.RentalFee)
+			}
+
+			if !charge.LateFee.Equal(decimal.RequireFromString(tt.late)) {
+				t.Errorf("late fee = %s, want %s", charge.LateFee, tt.late)
+			}
+
+			if charge.DaysLate != tt.daysLate {
+				t.Errorf("days late = %d, want %d", charge.DaysLate, tt.daysLate)
+			}
+
+			total := decimal.RequireFromString("2.99").Add(decimal.RequireFromString(tt.late))
+			if !charge.Total().Equal(total) {
+				t.Errorf("total = %s, want %s", charge.Total(), total)
+			}
+		})
+	}
+}
+
+func TestCalculateRoundsLateFee(t *testing.T) {
+
+	policy := Policy{PerDay: decimal.RequireFromString("0.333")}
+	rented := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	charge := policy.Calculate(Rental{
+		RentalRate:      decimal.RequireFromString("2.99"),
+		ReplacementCost: decimal.RequireFromString("20.00"),
+		RentalDuration:  1,
+		RentalDate:      rented,
+		ReturnDate:      DueDate(rented, 1).Add(2 * day),
+	})
+
+	if !charge.LateFee.Equal(decimal.RequireFromString("0.67")) {
+		t.Errorf("late fee = %s, want 0.67", charge.LateFee)
+	}
+}
+
+func TestDueDate(t *testing.T) {
+
+	rented := time.Date(2024, 2, 27, 10, 0, 0, 0, time.UTC)
+
+	want := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	if got := DueDate(rented, 3); !got.Equal(want) {
+		t.Errorf("DueDate = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// Decoder reads one record at a time. Next returns io.EOF after the last
+// record. A malformed record is returned as a *RecordError so that the
+// caller can report it and continue, any other error is fatal.
+type Decoder interface {
+	Next() (line int, record map[string]string, err error)
+}
+
+func NewDecoder(r io.Reader, format string) (Decoder, error) {
+
+	switch format {
+	case FormatCSV:
+		return newCSVDecoder(r)
+	case FormatNDJSON:
+		return newNDJSONDecoder(r), nil
+	}
+
+	return nil, fmt.Errorf("unsupported format %q, expected %s or %s", format, FormatCSV, FormatNDJSON)
+}
+
+type RecordError struct {
+	Err error
+}
+
+func (e *RecordError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+type csvDecoder struct {
+	reader *csv.Reader
+	header []string
+}
+
+// newCSVDecoder reads the header row, the column names are matched against
+// the json names of the create models.
+func newCSVDecoder(r io.Reader) (*csvDecoder, error) {
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error whiling read csv header: %w", err)
+	}
+
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+	}
+
+	return &csvDecoder{
+		reader: reader,
+		header: header,
+	}, nil
+}
+
+func (d *csvDecoder) Next() (int, map[string]string, error) {
+
+	row, err := d.reader.Read()
+
+	var parseErr *csv.ParseError
+
+	if errors.As(err, &parseErr) {
+		return parseErr.Line, nil, &RecordError{Err: err}
+	}
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	line, _ := d.reader.FieldPos(0)
+
+	if len(row) != len(d.header) {
+		return line, nil, &RecordError{Err: fmt.Errorf("expected %d columns, got %d", len(d.header), len(row))}
+	}
+
+	record := make(map[string]string, len(row))
+	for i, value := range row {
+		record[d.header[i]] = strings.TrimSpace(value)
+	}
+
+	return line, record, nil
+}
+
+type ndjsonDecoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newNDJSONDecoder(r io.Reader) *ndjsonDecoder {
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	return &ndjsonDecoder{scanner: scanner}
+}
+
+func (d *ndjsonDecoder) Next() (int, map[string]string, error) {
+
+	for d.scanner.Scan() {
+		d.line++
+
+		body := bytes.TrimSpace(d.scanner.Bytes())
+		if len(body) == 0 {
+			continue
+		}
+
+		var object map[string]interface{}
+
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+
+		err := decoder.Decode(&object)
+		if err != nil {
+			return d.line, nil, &RecordError{Err: fmt.Errorf("invalid json: %w", err)}
+		}
+
+		record := make(map[string]string, len(object))
+		for key, value := range object {
+			if value != nil {
+				record[strings.ToLower(key)] = fmt.Sprint(value)
+			}
+		}
+
+		return d.line, record, nil
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		return d.line, nil, err
+	}
+
+	return d.line, nil, io.EOF
+}
@@ -0,0 +1,118 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/jackc/pgconn"
+
+	"crud/storage"
+)
+
+// maxReportedErrors caps the row errors kept in a report, the failed
+// counter keeps counting past it.
+const maxReportedErrors = 1000
+
+type RowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type Report struct {
+	Entity  string     `json:"entity"`
+	Format  string     `json:"format"`
+	DryRun  bool       `json:"dry_run"`
+	Total   int        `json:"total"`
+	Valid   int        `json:"valid"`
+	Created int        `json:"created"`
+	Updated int        `json:"updated"`
+	Failed  int        `json:"failed"`
+	Errors  []RowError `json:"errors"`
+}
+
+// Target maps a record to a validated model and upserts it by its natural
+// key.
+type Target struct {
+	Entity string
+	Parse  func(record map[string]string) (interface{}, error)
+	Upsert func(ctx context.Context, item interface{}) (bool, error)
+}
+
+// Run streams every record of the decoder into the target. Invalid rows are
+// reported and skipped, a dry run only parses and validates. Any other
+// failure, e.g. a lost connection, stops the import with the rows counted so
+// far.
+func Run(ctx context.Context, decoder Decoder, target *Target, format string, dryRun bool) (*Report, error) {
+
+	report := &Report{
+		Entity: target.Entity,
+		Format: format,
+		DryRun: dryRun,
+		Errors: []RowError{},
+	}
+
+	for {
+		line, record, err := decoder.Next()
+		if errors.Is(err, io.EOF) {
+			return report, nil
+		}
+
+		var recordErr *RecordError
+		if err != nil && !errors.As(err, &recordErr) {
+			return report, err
+		}
+
+		report.Total++
+
+		if err == nil {
+			var item interface{}
+
+			item, err = target.Parse(record)
+			if err == nil {
+				report.Valid++
+
+				if !dryRun {
+					var created bool
+
+					created, err = target.Upsert(ctx, item)
+					if err != nil && !isRowError(err) {
+						return report, err
+					}
+
+					if err == nil && created {
+						report.Created++
+					} else if err == nil {
+						report.Updated++
+					}
+				}
+			}
+		}
+
+		if err != nil {
+			report.Failed++
+
+			if len(report.Errors) < maxReportedErrors {
+				report.Errors = append(report.Errors, RowError{Line: line, Error: err.Error()})
+			}
+		}
+	}
+}
+
+// isRowError reports whether an upsert failed because of the row itself: a
+// conflicting or missing reference mapped by the storage, or a value postgres
+// rejected, SQLSTATE class 22 (data exception) or 23 (integrity constraint
+// violation).
+func isRowError(err error) bool {
+
+	if errors.Is(err, storage.ErrAlreadyExists) ||
+		errors.Is(err, storage.ErrReferenceNotFound) ||
+		errors.Is(err, storage.ErrCategoryCycle) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23"))
+}
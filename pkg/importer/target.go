@@ -0,0 +1,126 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"crud/models"
+	"crud/storage"
+)
+
+func NewTarget(entity string, store storage.StorageI) (*Target, error) {
+
+	switch entity {
+	case "film":
+		return &Target{
+			Entity: entity,
+			Parse: func(record map[string]string) (interface{}, error) {
+
+				film := models.CreateFilm{
+					Title:              record["title"],
+					Description:        record["description"],
+					ReleaseYear:        record["release_year"],
+					LanguageId:         record["language_id"],
+					OriginalLanguageId: record["original_language_id"],
+					Rating:             record["rating"],
+				}
+
+				if record["duration"] != "" {
+					duration, err := strconv.Atoi(record["duration"])
+					if err != nil {
+						return nil, fmt.Errorf("invalid duration %q", record["duration"])
+					}
+					film.Duration = int32(duration)
+				}
+
+				if record["rental_duration"] != "" {
+					rentalDuration, err := strconv.Atoi(record["rental_duration"])
+					if err != nil {
+						return nil, fmt.Errorf("invalid rental_duration %q", record["rental_duration"])
+					}
+					film.RentalDuration = int32(rentalDuration)
+				}
+
+				for name, dest := range map[string]**decimal.Decimal{
+					"rental_rate":      &film.RentalRate,
+					"replacement_cost": &film.ReplacementCost,
+				} {
+					if record[name] == "" {
+						continue
+					}
+
+					amount, err := decimal.NewFromString(record[name])
+					if err != nil {
+						return nil, fmt.Errorf("invalid %s %q", name, record[name])
+					}
+					*dest = &amount
+				}
+
+				for _, feature := range strings.Split(record["special_features"], ",") {
+					feature = strings.TrimSpace(feature)
+					if feature != "" {
+						film.SpecialFeatures = append(film.SpecialFeatures, feature)
+					}
+				}
+
+				return &film, film.Validate()
+			},
+			Upsert: func(ctx context.Context, item interface{}) (bool, error) {
+				_, created, err := store.Film().Upsert(ctx, item.(*models.CreateFilm))
+				return created, err
+			},
+		}, nil
+	case "actor":
+		return &Target{
+			Entity: entity,
+			Parse: func(record map[string]string) (interface{}, error) {
+
+				actor := models.CreateActor{
+					First_name:  record["first_name"],
+					Last_name:   record["last_name"],
+					DisplayName: record["display_name"],
+					Biography:   record["biography"],
+					BirthDate:   record["birth_date"],
+					Birthplace:  record["birthplace"],
+					ExternalIds: map[string]string{},
+				}
+
+				// External ids come as one <source>_id column per source.
+				for source := range models.ActorExternalIds {
+					if id := record[source+"_id"]; id != "" {
+						actor.ExternalIds[source] = id
+					}
+				}
+
+				return &actor, actor.Validate()
+			},
+			Upsert: func(ctx context.Context, item interface{}) (bool, error) {
+				_, created, err := store.Actor().Upsert(ctx, item.(*models.CreateActor))
+				return created, err
+			},
+		}, nil
+	case "category":
+		return &Target{
+			Entity: entity,
+			Parse: func(record map[string]string) (interface{}, error) {
+
+				category := models.CreateCategory{
+					Name:     record["name"],
+					ParentId: record["parent_id"],
+				}
+
+				return &category, category.Validate()
+			},
+			Upsert: func(ctx context.Context, item interface{}) (bool, error) {
+				_, created, err := store.Category().Upsert(ctx, item.(*models.CreateCategory))
+				return created, err
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported entity %q", entity)
+}
@@ -0,0 +1,94 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type localStore struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStore keeps the files under dir. They have to be served under
+// baseURL, e.g. with gin's Static.
+func NewLocalStore(dir, baseURL string) BlobStore {
+	return &localStore{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Put writes to a temporary file first so that readers never see a partial
+// file.
+func (s *localStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(name), 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = io.Copy(file, io.LimitReader(body, size))
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(file.Name(), 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), name)
+}
+
+func (s *localStore) Delete(ctx context.Context, key string) error {
+
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *localStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// path maps the key to a file under dir, rejecting keys that would escape it.
+func (s *localStore) path(key string) (string, error) {
+
+	name := filepath.Join(s.dir, filepath.FromSlash(key))
+
+	if !strings.HasPrefix(name, filepath.Clean(s.dir)+string(filepath.Separator)) {
+		return "", errors.New("invalid key " + key)
+	}
+
+	return name, nil
+}
@@ -0,0 +1,81 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const (
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypeMP4  = "video/mp4"
+	TypeWebM = "video/webm"
+)
+
+// ErrUnsupportedType is returned by Sniff when the content is not one of the
+// allowed types.
+var ErrUnsupportedType = errors.New("unsupported content type")
+
+// BlobStore keeps uploaded files. The local store is enough for a single
+// instance, the S3 store works with AWS and compatible servers like MinIO.
+type BlobStore interface {
+	// Put stores size bytes of body under key, replacing what was there.
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Delete removes the key, deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL is where clients download the key from.
+	URL(key string) string
+}
+
+var extensions = map[string]string{
+	TypeJPEG: ".jpg",
+	TypePNG:  ".png",
+	TypeMP4:  ".mp4",
+	TypeWebM: ".webm",
+}
+
+// Extension returns the file extension used for keys of the content type.
+func Extension(contentType string) string {
+	return extensions[contentType]
+}
+
+// Sniff detects the content type of body from its first bytes, ignoring what
+// the client claims, and rewinds it. It fails with ErrUnsupportedType when
+// the type is not one of allowed.
+func Sniff(body io.ReadSeeker, allowed []string) (string, error) {
+
+	head := make([]byte, 512)
+
+	n, err := io.ReadFull(body, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	_, err = body.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", err
+	}
+
+	contentType := http.DetectContentType(head[:n])
+
+	for _, t := range allowed {
+		if t == contentType {
+			return contentType, nil
+		}
+	}
+
+	return "", ErrUnsupportedType
+}
+
+// ThumbnailKey is the key the thumbnail of the image stored under key is
+// stored under.
+func ThumbnailKey(key string) string {
+
+	ext := path.Ext(key)
+
+	return strings.TrimSuffix(key, ext) + "_thumb.jpg"
+}
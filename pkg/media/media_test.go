@@ -0,0 +1,133 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+
+	err := png.Encode(&buf, img)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestSniff(t *testing.T) {
+
+	var (
+		images = []string{TypeJPEG, TypePNG}
+		videos = []string{TypeMP4, TypeWebM}
+	)
+
+	tests := []struct {
+		name    string
+		body    []byte
+		allowed []string
+		want    string
+		wantErr error
+	}{
+		{"png", encodePNG(t, 2, 2), images, TypePNG, nil},
+		{"jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"), images, TypeJPEG, nil},
+		{"mp4", []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom"), videos, TypeMP4, nil},
+		{"webm", []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01"), videos, TypeWebM, nil},
+		{"image where a video is expected", encodePNG(t, 2, 2), videos, "", ErrUnsupportedType},
+		{"html named like an image", []byte("<html><script>alert(1)</script>"), images, "", ErrUnsupportedType},
+		{"empty", nil, images, "", ErrUnsupportedType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			body := bytes.NewReader(tt.body)
+
+			got, err := Sniff(body, tt.allowed)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("type = %q, want %q", got, tt.want)
+			}
+
+			rest, _ := io.ReadAll(body)
+			if !bytes.Equal(rest, tt.body) {
+				t.Error("body was not rewound")
+			}
+		})
+	}
+}
+
+func TestThumbnailKey(t *testing.T) {
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"film/1/poster/abc.png", "film/1/poster/abc_thumb.jpg"},
+		{"film/1/poster/abc.jpg", "film/1/poster/abc_thumb.jpg"},
+		{"actor/1.5/photo/abc", "actor/1.5/photo/abc_thumb.jpg"},
+	}
+
+	for _, tt := range tests {
+		if got := ThumbnailKey(tt.key); got != tt.want {
+			t.Errorf("ThumbnailKey(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		width      int
+		height     int
+		wantWidth  int
+		wantHeight int
+	}{
+		{"scaled down keeping the aspect ratio", 600, 900, 300, 450},
+		{"narrower image is kept", 200, 100, 200, 100},
+		{"height is at least one pixel", 3000, 2, 300, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			thumbnail, err := Thumbnail(bytes.NewReader(encodePNG(t, tt.width, tt.height)), 300)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := jpeg.DecodeConfig(bytes.NewReader(thumbnail))
+			if err != nil {
+				t.Fatalf("thumbnail is not a JPEG: %v", err)
+			}
+
+			if config.Width != tt.wantWidth || config.Height != tt.wantHeight {
+				t.Errorf("size = %dx%d, want %dx%d", config.Width, config.Height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+
+	_, err := Thumbnail(bytes.NewReader([]byte("not an image")), 300)
+	if err == nil {
+		t.Error("invalid image was accepted")
+	}
+}
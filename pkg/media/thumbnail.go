@@ -0,0 +1,100 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+)
+
+// maxPixels keeps a small file that decodes to a huge image from exhausting
+// the memory.
+const maxPixels = 50_000_000
+
+// Thumbnail decodes a JPEG or PNG image and returns it scaled down to width
+// as a JPEG, keeping the aspect ratio. Images that are narrower already are
+// only re-encoded.
+func Thumbnail(body io.ReadSeeker, width int) ([]byte, error) {
+
+	config, _, err := image.DecodeConfig(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Width*config.Height > maxPixels {
+		return nil, errors.New("image is too large")
+	}
+
+	_, err = body.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	src, _, err := image.Decode(body)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+
+	if bounds.Dx() > width {
+		height := bounds.Dy() * width / bounds.Dx()
+		if height < 1 {
+			height = 1
+		}
+		src = scale(src, width, height)
+	}
+
+	var buf bytes.Buffer
+
+	err = jpeg.Encode(&buf, src, &jpeg.Options{Quality: 85})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// scale shrinks src to width x height, every destination pixel is the
+// average of the source pixels it covers.
+func scale(src image.Image, width, height int) image.Image {
+
+	var (
+		bounds = src.Bounds()
+		dst    = image.NewRGBA(image.Rect(0, 0, width, height))
+	)
+
+	for y := 0; y < height; y++ {
+
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+
+		for x := 0; x < width; x++ {
+
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+
+			var r, g, b, a, n uint64
+
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Message is an outgoing notification, e.g. a password reset email.
+type Message struct {
+	To        string    `json:"to"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Sink interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New returns a file sink when path is set and a log sink otherwise.
+func New(path string) Sink {
+
+	if path == "" {
+		return &logSink{}
+	}
+
+	return &fileSink{path: path}
+}
+
+type fileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+// Send appends the message to the file as one JSON line.
+func (s *fileSink) Send(ctx context.Context, msg Message) error {
+
+	if msg.CreatedAt.IsZero() {
+		msg.CreatedAt = time.Now()
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(body, '\n'))
+
+	return err
+}
+
+type logSink struct{}
+
+func (s *logSink) Send(ctx context.Context, msg Message) error {
+	log.Printf("outbox: to=%s subject=%q body=%q\n", msg.To, msg.Subject, msg.Body)
+	return nil
+}
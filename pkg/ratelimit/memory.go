@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  Limit
+}
+
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets:   map[string]*bucket{},
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *memoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		s.buckets[key] = b
+	}
+
+	tokens, result := take(b.tokens, now.Sub(b.last), limit)
+
+	b.tokens = tokens
+	b.last = now
+	b.limit = limit
+
+	return result, nil
+}
+
+// sweep drops buckets that have refilled completely, they are
+// indistinguishable from a new bucket.
+func (s *memoryStore) sweep(now time.Time) {
+
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, b := range s.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.lastSweep = now
+}
@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit describes a token bucket: Rate tokens are added per second up to
+// Burst tokens.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Store keeps the bucket state. The in-process MemoryStore is enough for a
+// single instance, RedisStore shares buckets between instances.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// take applies one request to a bucket holding tokens that were last
+// refilled elapsed ago and returns the new token count with the result.
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, Result) {
+
+	burst := float64(limit.Burst)
+
+	tokens = math.Min(burst, tokens+elapsed.Seconds()*limit.Rate)
+
+	result := Result{Limit: limit.Burst}
+
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+
+	result.Remaining = int(math.Floor(tokens))
+	result.Reset = seconds((burst - tokens) / limit.Rate)
+
+	return tokens, result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
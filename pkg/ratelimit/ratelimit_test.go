@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTake(t *testing.T) {
+
+	limit := Limit{Rate: 1, Burst: 5}
+
+	tests := []struct {
+		name       string
+		limit      Limit
+		tokens     float64
+		elapsed    time.Duration
+		wantTokens float64
+		want       Result
+	}{
+		{
+			name:       "full bucket",
+			limit:      limit,
+			tokens:     5,
+			wantTokens: 4,
+			want:       Result{Allowed: true, Limit: 5, Remaining: 4, Reset: time.Second},
+		},
+		{
+			name:       "empty bucket",
+			limit:      limit,
+			tokens:     0,
+			wantTokens: 0,
+			want:       Result{Limit: 5, Remaining: 0, Reset: 5 * time.Second, RetryAfter: time.Second},
+		},
+		{
+			name:       "partial refill is not enough",
+			limit:      limit,
+			tokens:     0,
+			elapsed:    500 * time.Millisecond,
+			wantTokens: 0.5,
+			want:       Result{Limit: 5, Remaining: 0, Reset: 4500 * time.Millisecond, RetryAfter: 500 * time.Millisecond},
+		},
+		{
+			name:       "refill to one token",
+			limit:      limit,
+			tokens:     0,
+			elapsed:    time.Second,
+			wantTokens: 0,
+			want:       Result{Allowed: true, Limit: 5, Remaining: 0, Reset: 5 * time.Second},
+		},
+		{
+			name:       "refill is capped at burst",
+			limit:      limit,
+			tokens:     2,
+			elapsed:    time.Hour,
+			wantTokens: 4,
+			want:       Result{Allowed: true, Limit: 5, Remaining: 4, Reset: time.Second},
+		},
+		{
+			name:       "retry after scales with rate",
+			limit:      Limit{Rate: 2, Burst: 10},
+			tokens:     0.5,
+			wantTokens: 0.5,
+			want:       Result{Limit: 10, Remaining: 0, Reset: 4750 * time.Millisecond, RetryAfter: 250 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			tokens, got := take(tt.tokens, tt.elapsed, tt.limit)
+
+			if tokens != tt.wantTokens {
+				t.Errorf("tokens = %v, want %v", tokens, tt.wantTokens)
+			}
+
+			if got != tt.want {
+				t.Errorf("result = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreBurst(t *testing.T) {
+
+	var (
+		ctx   = context.Background()
+		store = NewMemoryStore()
+		limit = Limit{Rate: 0.01, Burst: 2}
+	)
+
+	for i, want := range []bool{true, true, false} {
+		result, err := store.Take(ctx, "client", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.Allowed != want {
+			t.Errorf("request %d: allowed = %v, want %v", i+1, result.Allowed, want)
+		}
+	}
+
+	result, err := store.Take(ctx, "other", limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Allowed {
+		t.Error("buckets are not separated by key")
+	}
+}
@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Scripter is the subset of a Redis client needed by RedisStore. Any client
+// that can run EVAL (go-redis, redigo, ...) can be adapted to it.
+type Scripter interface {
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+}
+
+// tokenBucketScript mirrors take() so that all instances share one bucket.
+// It returns {allowed, remaining, reset_ms, retry_after_ms}.
+const tokenBucketScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "last")
+local tokens = tonumber(state[1]) or burst
+local last = tonumber(state[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - last) / 1000 * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate * 1000)
+end
+
+local reset = math.ceil((burst - tokens) / rate * 1000)
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "last", tostring(now))
+redis.call("PEXPIRE", KEYS[1], reset + 1000)
+
+return {allowed, math.floor(tokens), reset, retry}
+`
+
+type redisStore struct {
+	client Scripter
+	prefix string
+}
+
+func NewRedisStore(client Scripter, prefix string) Store {
+	return &redisStore{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (s *redisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+
+	reply, err := s.client.Eval(ctx, tokenBucketScript, []string{s.prefix + key},
+		limit.Rate,
+		limit.Burst,
+		time.Now().UnixMilli(),
+	)
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 4 {
+		return Result{}, fmt.Errorf("unexpected rate limit reply: %v", reply)
+	}
+
+	nums := make([]int64, len(values))
+	for i, value := range values {
+		num, ok := value.(int64)
+		if !ok {
+			return Result{}, errors.New("unexpected rate limit reply type")
+		}
+		nums[i] = num
+	}
+
+	return Result{
+		Allowed:    nums[0] == 1,
+		Limit:      limit.Burst,
+		Remaining:  int(nums[1]),
+		Reset:      time.Duration(nums[2]) * time.Millisecond,
+		RetryAfter: time.Duration(nums[3]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package rbac
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+const (
+	RoleAnonymous = "anonymous"
+
+	ActionRead   = "read"
+	ActionWrite  = "write"
+	ActionDelete = "delete"
+	ActionAdmin  = "admin"
+)
+
+// Policy maps a role name to the permissions granted to it.
+// A permission is written as "<entity>:<action>", "<entity>:admin" grants
+// every action on the entity and "*" grants everything.
+type Policy map[string][]string
+
+func LoadPolicy(path string) (Policy, error) {
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy Policy
+
+	err = json.Unmarshal(body, &policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func Permission(entity, action string) string {
+	return entity + ":" + action
+}
+
+// Allowed reports whether any of the roles or the directly granted
+// permissions cover the required permission.
+func (p Policy) Allowed(roles []string, granted []string, required string) bool {
+
+	for _, role := range roles {
+		if Match(p[role], required) {
+			return true
+		}
+	}
+
+	return Match(granted, required)
+}
+
+func Match(permissions []string, required string) bool {
+
+	entity := strings.SplitN(required, ":", 2)[0]
+
+	for _, permission := range permissions {
+		if permission == "*" || permission == required || permission == Permission(entity, ActionAdmin) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+package rbac
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		permissions []string
+		required    string
+		want        bool
+	}{
+		{"exact", []string{"film:read"}, "film:read", true},
+		{"other action", []string{"film:read"}, "film:write", false},
+		{"other entity", []string{"actor:read"}, "film:read", false},
+		{"entity admin", []string{"film:admin"}, "film:delete", true},
+		{"other entity admin", []string{"actor:admin"}, "film:delete", false},
+		{"wildcard", []string{"*"}, "category:delete", true},
+		{"entity wildcard is not special", []string{"film:*"}, "film:read", false},
+		{"prefix is not a match", []string{"film"}, "film:read", false},
+		{"none", nil, "film:read", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(tt.permissions, tt.required); got != tt.want {
+				t.Errorf("Match(%v, %q) = %v, want %v", tt.permissions, tt.required, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyAllowed(t *testing.T) {
+
+	policy := Policy{
+		RoleAnonymous: {"film:read"},
+		"editor":      {"film:write", "actor:admin"},
+		"admin":       {"*"},
+	}
+
+	tests := []struct {
+		name     string
+		roles    []string
+		granted  []string
+		required string
+		want     bool
+	}{
+		{"anonymous read", []string{RoleAnonymous}, nil, "film:read", true},
+		{"anonymous write", []string{RoleAnonymous}, nil, "film:write", false},
+		{"any role is enough", []string{RoleAnonymous, "editor"}, nil, "actor:delete", true},
+		{"admin", []string{"admin"}, nil, "category:delete", true},
+		{"unknown role", []string{"ghost"}, nil, "film:read", false},
+		{"granted directly", nil, []string{"category:write"}, "category:write", true},
+		{"granted does not widen", nil, []string{"category:write"}, "category:delete", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Allowed(tt.roles, tt.granted, tt.required); got != tt.want {
+				t.Errorf("Allowed(%v, %v, %q) = %v, want %v", tt.roles, tt.granted, tt.required, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Package reports holds what the report endpoints share apart from their
+// SQL, which lives in the storage: parsing of the query parameters, the
+// layout of every report in the export formats and the schedule refreshing
+// the materialized views the heavy reports are read from.
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"crud/models"
+	"crud/pkg/export"
+)
+
+const (
+	FormatJSON = "json"
+	DateLayout = "2006-01-02"
+)
+
+// ParseFormat checks the requested format, JSON when empty or one of the
+// export formats.
+func ParseFormat(format string) (string, error) {
+
+	if format == "" {
+		return FormatJSON, nil
+	}
+
+	if format != FormatJSON && !export.IsFormat(format) {
+		return "", fmt.Errorf("unsupported format %q", format)
+	}
+
+	return format, nil
+}
+
+// ParsePeriod parses the inclusive from and to dates into a half open
+// period, either may be left out.
+func ParsePeriod(from string, to string) (models.ReportPeriod, error) {
+
+	var (
+		period models.ReportPeriod
+		err    error
+	)
+
+	if from != "" {
+		period.From, err = time.Parse(DateLayout, from)
+		if err != nil {
+			return period, errors.New("from must be a date like 2006-01-02")
+		}
+	}
+
+	if to != "" {
+		period.To, err = time.Parse(DateLayout, to)
+		if err != nil {
+			return period, errors.New("to must be a date like 2006-01-02")
+		}
+
+		period.To = period.To.AddDate(0, 0, 1)
+	}
+
+	if !period.From.IsZero() && !period.To.IsZero() && !period.From.Before(period.To) {
+		return period, errors.New("from must not be after to")
+	}
+
+	return period, nil
+}
+
+// Refresher refreshes the snapshots the reports are read from.
+type Refresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// RefreshEvery refreshes the report snapshots every interval, it never
+// returns. A failed refresh is logged and retried on the next tick.
+func RefreshEvery(interval time.Duration, refresher Refresher) {
+
+	for range time.Tick(interval) {
+		err := refresher.Refresh(context.Background())
+		if err != nil {
+			log.Printf("error whiling refresh reports: %v\n", err)
+		}
+	}
+}
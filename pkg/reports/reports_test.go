@@ -0,0 +1,102 @@
+package reports
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"crud/models"
+	"crud/pkg/export"
+)
+
+func TestParsePeriod(t *testing.T) {
+
+	var (
+		march1 = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		march2 = time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		want    models.ReportPeriod
+		wantErr bool
+	}{
+		{"open", "", "", models.ReportPeriod{}, false},
+		{"from only", "2024-03-01", "", models.ReportPeriod{From: march1}, false},
+		{"to is inclusive", "", "2024-03-01", models.ReportPeriod{To: march2}, false},
+		{"one day", "2024-03-01", "2024-03-01", models.ReportPeriod{From: march1, To: march2}, false},
+		{"from after to", "2024-03-02", "2024-03-01", models.ReportPeriod{}, true},
+		{"invalid from", "01.03.2024", "", models.ReportPeriod{}, true},
+		{"invalid to", "", "2024-13-01", models.ReportPeriod{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			got, err := ParsePeriod(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && (!got.From.Equal(tt.want.From) || !got.To.Equal(tt.want.To)) {
+				t.Errorf("period = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{"", FormatJSON, false},
+		{"json", FormatJSON, false},
+		{"csv", export.FormatCSV, false},
+		{"xlsx", export.FormatXLSX, false},
+		{"pdf", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.format)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q, error %v", tt.format, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestTopFilmsCSV(t *testing.T) {
+
+	table := TopFilms(&models.GetTopFilmsResponse{Films: []*models.TopFilm{
+		{FilmId: "1", Title: "Academy Dinosaur", Rentals: 3, Revenue: decimal.RequireFromString("8.97")},
+		{FilmId: "2", Title: "Ace, Goldfinger", Rentals: 1, Revenue: decimal.RequireFromString("4.9")},
+	}})
+
+	var buf bytes.Buffer
+
+	writer, err := export.NewWriter(&buf, export.FormatCSV, table.Name, table.Columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = table.WriteRows(writer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "film_id,title,rentals,revenue\n1,Academy Dinosaur,3,8.97\n2,\"Ace, Goldfinger\",1,4.90\n"
+	if buf.String() != want {
+		t.Errorf("csv =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,121 @@
+package reports
+
+import (
+	"strconv"
+
+	"crud/models"
+	"crud/pkg/export"
+)
+
+// Table lays a report out for the export formats: the file name, the
+// columns and the rows in the order of the columns.
+type Table struct {
+	Name    string
+	Columns []string
+	rows    func(writer export.Writer) error
+}
+
+// WriteRows writes every row of the report to writer.
+func (t *Table) WriteRows(writer export.Writer) error {
+	return t.rows(writer)
+}
+
+func TopFilms(resp *models.GetTopFilmsResponse) *Table {
+	return &Table{
+		Name:    "top-films",
+		Columns: []string{"film_id", "title", "rentals", "revenue"},
+		rows: func(writer export.Writer) error {
+			for _, film := range resp.Films {
+				err := writer.Write(film, []string{
+					film.FilmId,
+					film.Title,
+					strconv.Itoa(int(film.Rentals)),
+					film.Revenue.StringFixed(2),
+				})
+
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func CategoryRevenue(resp *models.GetCategoryRevenueResponse) *Table {
+	return &Table{
+		Name:    "revenue-by-category",
+		Columns: []string{"category_id", "name", "films", "rentals", "revenue", "share"},
+		rows: func(writer export.Writer) error {
+			for _, category := range resp.Categories {
+				err := writer.Write(category, []string{
+					category.CategoryId,
+					category.Name,
+					strconv.Itoa(int(category.Films)),
+					strconv.Itoa(int(category.Rentals)),
+					category.Revenue.StringFixed(2),
+					category.Share.StringFixed(4),
+				})
+
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func ActorPopularity(resp *models.GetActorPopularityResponse) *Table {
+	return &Table{
+		Name:    "actor-popularity",
+		Columns: []string{"actor_id", "first_name", "last_name", "films", "rentals", "revenue"},
+		rows: func(writer export.Writer) error {
+			for _, actor := range resp.Actors {
+				err := writer.Write(actor, []string{
+					actor.ActorId,
+					actor.FirstName,
+					actor.LastName,
+					strconv.Itoa(int(actor.Films)),
+					strconv.Itoa(int(actor.Rentals)),
+					actor.Revenue.StringFixed(2),
+				})
+
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func StoreRevenue(resp *models.GetStoreRevenueResponse) *Table {
+	return &Table{
+		Name:    "store-revenue",
+		Columns: []string{"store_id", "store_name", "staff_id", "staff_name", "payments", "revenue", "refunded", "net"},
+		rows: func(writer export.Writer) error {
+			for _, store := range resp.Stores {
+				err := writer.Write(store, []string{
+					store.StoreId,
+					store.StoreName,
+					store.StaffId,
+					store.StaffName,
+					strconv.Itoa(int(store.Payments)),
+					store.Revenue.StringFixed(2),
+					store.Refunded.StringFixed(2),
+					store.Net.StringFixed(2),
+				})
+
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,17 @@
+package security
+
+const apiKeyPrefix = "ck_"
+
+// GenerateApiKey returns a new plaintext key together with its display
+// prefix and the hash that is stored instead of the key itself.
+func GenerateApiKey() (key string, prefix string, hash string, err error) {
+
+	token, _, err := GenerateToken()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	key = apiKeyPrefix + token
+
+	return key, key[:len(apiKeyPrefix)+8], HashToken(key), nil
+}
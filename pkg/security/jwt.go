@@ -0,0 +1,51 @@
+package security
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+type Claims struct {
+	Roles []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+func GenerateJWT(subject string, roles []string, ttl time.Duration, secretKey string) (string, error) {
+
+	now := time.Now()
+
+	claims := Claims{
+		Roles: roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secretKey))
+}
+
+func ParseJWT(tokenStr string, secretKey string) (*Claims, error) {
+
+	var claims Claims
+
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(secretKey), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,29 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// GenerateToken returns a random opaque token and its sha256 hash.
+// Only the hash is meant to be persisted.
+func GenerateToken() (token string, hash string, err error) {
+
+	buf := make([]byte, 32)
+
+	_, err = rand.Read(buf)
+	if err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+
+	return token, HashToken(token), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -3,20 +3,23 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/jackc/pgx/v4"
 
 	"crud/models"
 	"crud/pkg/helper"
+	"crud/storage"
 )
 
 type actorRepo struct {
-	db *pgxpool.Pool
+	db DB
 }
 
-func NewActorRepo(db *pgxpool.Pool) *actorRepo {
+func NewActorRepo(db DB) *actorRepo {
 	return &actorRepo{
 		db: db,
 	}
@@ -24,6 +27,8 @@ func NewActorRepo(db *pgxpool.Pool) *actorRepo {
 
 func (f *actorRepo) Create(ctx context.Context, actor *models.CreateActor) (string, error) {
 
+	actor.ApplyDefaults()
+
 	var (
 		id    = uuid.New().String()
 		query string
@@ -34,16 +39,30 @@ func (f *actorRepo) Create(ctx context.Context, actor *models.CreateActor) (stri
 			actor_id,
 			first_name,
 			last_name,
+			display_name,
+			biography,
+			birth_date,
+			birthplace,
+			external_ids,
 			updated_at
-		) VALUES ( $1, $2, $3, now() )
+		) VALUES ( $1, $2, $3, $4, $5, $6, $7, $8, now() )
 	`
 
 	_, err := f.db.Exec(ctx, query,
 		id,
 		actor.First_name,
 		actor.Last_name,
+		nullIfEmpty(actor.DisplayName),
+		actor.Biography,
+		nullIfEmpty(actor.BirthDate),
+		actor.Birthplace,
+		actor.ExternalIds,
 	)
 
+	if isUniqueViolation(err) {
+		return "", storage.ErrAlreadyExists
+	}
+
 	if err != nil {
 		return "", err
 	}
@@ -51,48 +70,32 @@ func (f *actorRepo) Create(ctx context.Context, actor *models.CreateActor) (stri
 	return id, nil
 }
 
-func (f *actorRepo) GetByPKey(ctx context.Context, pkey *models.ActorPrimarKey) (*models.Actor, error) {
+// actorColumns is shared by every query that returns whole actors, the
+// actor table has to be aliased as a.
+const actorColumns = `
+			a.actor_id,
+			a.first_name,
+			a.last_name,
+			COALESCE(a.display_name, a.first_name || ' ' || a.last_name),
+			a.biography,
+			TO_CHAR(a.birth_date, 'YYYY-MM-DD'),
+			a.birthplace,
+			a.external_ids,
+			a.photo_url,
+			a.photo_thumbnail_url,
+			a.created_at,
+			a.updated_at
+`
 
-	var (
-		id         sql.NullString
-		first_name sql.NullString
-		last_name  sql.NullString
-		createdAt  sql.NullString
-		updatedAt  sql.NullString
-	)
+func (f *actorRepo) GetByPKey(ctx context.Context, pkey *models.ActorPrimarKey) (*models.Actor, error) {
 
-	query := `
-		SELECT
-			actor_id,
-			first_name,
-			last_name,
-			created_at,
-			updated_at
+	query := `SELECT` + actorColumns + `
 		FROM
-			actor
-		WHERE actor_id = $1
+			actor a
+		WHERE a.actor_id = $1
 	`
 
-	err := f.db.QueryRow(ctx, query, pkey.Id).
-		Scan(
-			&id,
-			&first_name,
-			&last_name,
-			&createdAt,
-			&updatedAt,
-		)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return &models.Actor{
-		Id:         id.String,
-		First_name: first_name.String,
-		Last_name:  last_name.String,
-		CreatedAt:  createdAt.String,
-		UpdatedAt:  updatedAt.String,
-	}, nil
+	return scanActor(f.db.QueryRow(ctx, query, pkey.Id))
 }
 
 func (f *actorRepo) GetList(ctx context.Context, req *models.GetListActorRequest) (*models.GetListActorResponse, error) {
@@ -111,60 +114,104 @@ func (f *actorRepo) GetList(ctx context.Context, req *models.GetListActorRequest
 		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
 	}
 
-	query := `
-		SELECT
-			COUNT(*) OVER(),
-			actor_id,
-			first_name,
-			last_name,
-			created_at,
-			updated_at
+	where, args := actorFilter(req)
+
+	query := `SELECT COUNT(*) OVER(),` + actorColumns + `
 		FROM
-			actor
-	`
+			actor a
+	` + where
 
 	query += offset + limit
 
-	rows, err := f.db.Query(ctx, query)
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 
-		var (
-			id         sql.NullString
-			first_name sql.NullString
-			last_name  sql.NullString
-			createdAt  sql.NullString
-			updatedAt  sql.NullString
-		)
+		actor, err := scanActor(rows, &resp.Count)
+		if err != nil {
+			return nil, err
+		}
 
-		err := rows.Scan(
-			&resp.Count,
-			&id,
-			&first_name,
-			&last_name,
-			&createdAt,
-			&updatedAt,
-		)
+		resp.Actors = append(resp.Actors, actor)
+	}
+
+	return &resp, rows.Err()
+}
+
+// Export streams every actor matching the list request to fn row by row
+// instead of collecting them like GetList. Limit and offset are optional.
+func (f *actorRepo) Export(ctx context.Context, req *models.GetListActorRequest, fn func(*models.Actor) error) error {
+
+	where, args := actorFilter(req)
+
+	query := `SELECT` + actorColumns + `
+		FROM
+			actor a
+	` + where + `
+		ORDER BY a.created_at
+	`
+
+	if req.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	if req.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
 
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		actor, err := scanActor(rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		resp.Actors = append(resp.Actors, &models.Actor{
-			Id:         id.String,
-			First_name: first_name.String,
-			Last_name:  last_name.String,
-			CreatedAt:  createdAt.String,
-			UpdatedAt:  updatedAt.String,
-		})
+		err = fn(actor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// actorFilter turns the optional list filters into a WHERE clause and its
+// args. The birth year is a range so that the birth_date index is used.
+func actorFilter(req *models.GetListActorRequest) (string, []interface{}) {
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if req.BirthYear > 0 {
+		args = append(args, req.BirthYear)
+		conditions = append(conditions, fmt.Sprintf(
+			"a.birth_date >= make_date($%d, 1, 1) AND a.birth_date < make_date($%d + 1, 1, 1)", len(args), len(args),
+		))
+	}
 
+	if len(conditions) == 0 {
+		return "", nil
 	}
 
-	return &resp, err
+	return " WHERE " + strings.Join(conditions, " AND "), args
 }
 
 func (f *actorRepo) Update(ctx context.Context, id string, req *models.UpdateActor) (int64, error) {
 
+	req.ApplyDefaults()
+
 	var (
 		query  = ""
 		params map[string]interface{}
@@ -176,19 +223,33 @@ func (f *actorRepo) Update(ctx context.Context, id string, req *models.UpdateAct
 		SET
 			first_name = :first_name,
 			last_name = :last_name,
+			display_name = :display_name,
+			biography = :biography,
+			birth_date = :birth_date,
+			birthplace = :birthplace,
+			external_ids = :external_ids,
 			updated_at = now()
 		WHERE actor_id = :actor_id
 	`
 
 	params = map[string]interface{}{
-		"actor_id":   id,
-		"first_name": req.First_name,
-		"last_name":  req.Last_name,
+		"actor_id":     id,
+		"first_name":   req.First_name,
+		"last_name":    req.Last_name,
+		"display_name": nullIfEmpty(req.DisplayName),
+		"biography":    req.Biography,
+		"birth_date":   nullIfEmpty(req.BirthDate),
+		"birthplace":   req.Birthplace,
+		"external_ids": req.ExternalIds,
 	}
 
 	query, args := helper.ReplaceQueryParams(query, params)
 
 	rowsAffected, err := f.db.Exec(ctx, query, args...)
+	if isUniqueViolation(err) {
+		return 0, storage.ErrAlreadyExists
+	}
+
 	if err != nil {
 		return 0, err
 	}
@@ -196,12 +257,499 @@ func (f *actorRepo) Update(ctx context.Context, id string, req *models.UpdateAct
 	return rowsAffected.RowsAffected(), nil
 }
 
-func (f *actorRepo) Delete(ctx context.Context, req *models.ActorPrimarKey) error {
+// SetPhoto points the actor at a new photo and returns the key of the one it
+// replaced, empty when they had none. It fails with pgx.ErrNoRows when the
+// actor does not exist.
+func (f *actorRepo) SetPhoto(ctx context.Context, id string, photo *models.MediaAsset) (string, error) {
+
+	var previous sql.NullString
+
+	query := `
+		UPDATE
+			actor a
+		SET
+			photo_key = $2,
+			photo_url = $3,
+			photo_thumbnail_url = $4,
+			updated_at = now()
+		FROM (
+			SELECT actor_id, photo_key FROM actor WHERE actor_id = $1 FOR UPDATE
+		) old
+		WHERE a.actor_id = old.actor_id
+		RETURNING old.photo_key
+	`
+
+	err := f.db.QueryRow(ctx, query, id, photo.Key, photo.Url, photo.ThumbnailUrl).Scan(&previous)
+
+	return previous.String, err
+}
+
+// Delete returns the photo the actor had.
+func (f *actorRepo) Delete(ctx context.Context, req *models.ActorPrimarKey) ([]*models.MediaAsset, error) {
+
+	assets, err := scanDeleted(f.db.QueryRow(ctx, deleteQuery("actor", "actor_id", actorMedia), req.Id), actorMedia)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return assets, err
+}
+
+// Upsert updates the actor with the same first and last name, replacing the
+// profile like Update, or inserts a new one. Names are not unique, the
+// oldest actor with the name is updated. It reports whether a new row was
+// created.
+func (f *actorRepo) Upsert(ctx context.Context, actor *models.CreateActor) (string, bool, error) {
+
+	actor.ApplyDefaults()
+
+	var id string
+
+	tx, err := f.db.Begin(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Without a unique key to conflict on, two upserts of the same new
+	// name would both insert, serialize them per name instead.
+	_, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('actor.name'), hashtext($1 || ' ' || $2))",
+		actor.First_name,
+		actor.Last_name,
+	)
+
+	if err != nil {
+		return "", false, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		SELECT
+			actor_id
+		FROM
+			actor
+		WHERE first_name = $1 AND last_name = $2
+		ORDER BY created_at, actor_id
+		LIMIT 1
+	`, actor.First_name, actor.Last_name).Scan(&id)
+
+	created := errors.Is(err, pgx.ErrNoRows)
+
+	if created {
+		id = uuid.New().String()
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO actor(
+				actor_id,
+				first_name,
+				last_name,
+				display_name,
+				biography,
+				birth_date,
+				birthplace,
+				external_ids,
+				updated_at
+			) VALUES ( $1, $2, $3, $4, $5, $6, $7, $8, now() )
+		`,
+			id,
+			actor.First_name,
+			actor.Last_name,
+			nullIfEmpty(actor.DisplayName),
+			actor.Biography,
+			nullIfEmpty(actor.BirthDate),
+			actor.Birthplace,
+			actor.ExternalIds,
+		)
+	} else if err == nil {
+		_, err = tx.Exec(ctx, `
+			UPDATE
+				actor
+			SET
+				display_name = $2,
+				biography = $3,
+				birth_date = $4,
+				birthplace = $5,
+				external_ids = $6,
+				updated_at = now()
+			WHERE actor_id = $1
+		`,
+			id,
+			nullIfEmpty(actor.DisplayName),
+			actor.Biography,
+			nullIfEmpty(actor.BirthDate),
+			actor.Birthplace,
+			actor.ExternalIds,
+		)
+	}
+
+	if isUniqueViolation(err) {
+		return "", false, storage.ErrAlreadyExists
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	return id, created, tx.Commit(ctx)
+}
+
+// FindSimilar returns actors whose full name has a trigram similarity of at
+// least threshold with name, most similar first.
+func (f *actorRepo) FindSimilar(ctx context.Context, name string, threshold float32, limit int32) ([]*models.SimilarActor, error) {
+
+	var resp = []*models.SimilarActor{}
 
-	_, err := f.db.Exec(ctx, "DELETE FROM actor WHERE actor_id = $1", req.Id)
+	tx, err := f.db.Begin(ctx)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// The % operator uses the index but reads its threshold from the
+	// session, set it for this transaction only.
+	_, err = tx.Exec(ctx, "SELECT set_config('pg_trgm.similarity_threshold', $1, true)", fmt.Sprint(threshold))
+	if err != nil {
+		return nil, err
 	}
 
-	return err
+	query := `
+		SELECT
+			similarity(a.first_name || ' ' || a.last_name, $1) AS score,` + actorColumns + `
+		FROM
+			actor a
+		WHERE (a.first_name || ' ' || a.last_name) % $1
+		ORDER BY score DESC, a.last_name, a.first_name
+		LIMIT $2
+	`
+
+	rows, err := tx.Query(ctx, query, name, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var score float32
+
+		actor, err := scanActor(rows, &score)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, &models.SimilarActor{
+			Actor:      *actor,
+			Similarity: score,
+		})
+	}
+
+	return resp, rows.Err()
+}
+
+// Merge folds the duplicate actor into the survivor: film links are moved,
+// earlier merges into the duplicate are re-pointed, the merge is recorded,
+// the survivor's profile gaps are filled from the duplicate and the
+// duplicate is deleted. Both actors are locked for the duration. It returns
+// the duplicate's photo when the survivor kept its own, to be removed from
+// the blob store after commit.
+func (f *actorRepo) Merge(ctx context.Context, duplicateId string, req *models.MergeActor, mergedBy string) (*models.ActorMerge, []*models.MediaAsset, error) {
+
+	var (
+		merge = models.ActorMerge{
+			MergedActorId:   duplicateId,
+			SurvivorActorId: req.SurvivorId,
+			MergedBy:        mergedBy,
+		}
+		found    int
+		mergedAt sql.NullString
+	)
+
+	tx, err := f.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock in a stable order so that two concurrent merges of the same
+	// pair can not deadlock.
+	rows, err := tx.Query(ctx, `
+		SELECT
+			actor_id,
+			first_name,
+			last_name
+		FROM
+			actor
+		WHERE actor_id IN ($1, $2)
+		ORDER BY actor_id
+		FOR UPDATE
+	`, duplicateId, req.SurvivorId)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var (
+			id         string
+			first_name sql.NullString
+			last_name  sql.NullString
+		)
+
+		err = rows.Scan(&id, &first_name, &last_name)
+		if err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+
+		if id == duplicateId {
+			merge.First_name = first_name.String
+			merge.Last_name = last_name.String
+		}
+
+		found++
+	}
+	rows.Close()
+
+	if rows.Err() != nil {
+		return nil, nil, rows.Err()
+	}
+
+	if found != 2 {
+		return nil, nil, pgx.ErrNoRows
+	}
+
+	queries := []string{
+		`INSERT INTO film_actor (film_id, actor_id)
+			SELECT film_id, $2 FROM film_actor WHERE actor_id = $1
+			ON CONFLICT DO NOTHING`,
+		`UPDATE actor_merge SET survivor_actor_id = $2 WHERE survivor_actor_id = $1`,
+	}
+
+	for _, query := range queries {
+		_, err = tx.Exec(ctx, query, duplicateId, req.SurvivorId)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	query := `
+		INSERT INTO actor_merge(
+			merged_actor_id,
+			survivor_actor_id,
+			first_name,
+			last_name,
+			merged_by
+		) VALUES ( $1, $2, $3, $4, $5 )
+		RETURNING merged_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		duplicateId,
+		req.SurvivorId,
+		merge.First_name,
+		merge.Last_name,
+		mergedBy,
+	).Scan(&mergedAt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merge.MergedAt = mergedAt.String
+
+	// The duplicate goes first, the survivor can only take over its
+	// external ids once nobody else holds them.
+	var (
+		displayName       sql.NullString
+		biography         sql.NullString
+		birthDate         sql.NullString
+		birthplace        sql.NullString
+		externalIds       map[string]string
+		photoKey          sql.NullString
+		photoUrl          sql.NullString
+		photoThumbnailUrl sql.NullString
+		survivorPhotoKey  string
+	)
+
+	err = tx.QueryRow(ctx, `
+		DELETE FROM actor
+		WHERE actor_id = $1
+		RETURNING
+			display_name,
+			biography,
+			TO_CHAR(birth_date, 'YYYY-MM-DD'),
+			birthplace,
+			external_ids,
+			photo_key,
+			photo_url,
+			photo_thumbnail_url
+	`, duplicateId).Scan(
+		&displayName,
+		&biography,
+		&birthDate,
+		&birthplace,
+		&externalIds,
+		&photoKey,
+		&photoUrl,
+		&photoThumbnailUrl,
+	)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if externalIds == nil {
+		externalIds = map[string]string{}
+	}
+
+	// Every profile field the survivor has wins, the duplicate only fills
+	// the gaps. Its photo is taken over when the survivor has none.
+	err = tx.QueryRow(ctx, `
+		UPDATE
+			actor
+		SET
+			display_name = COALESCE(display_name, $2),
+			biography = CASE WHEN biography = '' THEN $3 ELSE biography END,
+			birth_date = COALESCE(birth_date, $4::DATE),
+			birthplace = CASE WHEN birthplace = '' THEN $5 ELSE birthplace END,
+			external_ids = $6::JSONB || external_ids,
+			photo_key = COALESCE(photo_key, $7),
+			photo_url = CASE WHEN photo_key IS NULL THEN $8 ELSE photo_url END,
+			photo_thumbnail_url = CASE WHEN photo_key IS NULL THEN $9 ELSE photo_thumbnail_url END,
+			updated_at = now()
+		WHERE actor_id = $1
+		RETURNING COALESCE(photo_key, '')
+	`,
+		req.SurvivorId,
+		nullIfEmpty(displayName.String),
+		biography.String,
+		nullIfEmpty(birthDate.String),
+		birthplace.String,
+		externalIds,
+		nullIfEmpty(photoKey.String),
+		nullIfEmpty(photoUrl.String),
+		nullIfEmpty(photoThumbnailUrl.String),
+	).Scan(&survivorPhotoKey)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var orphaned []*models.MediaAsset
+
+	if photoKey.String != "" && photoKey.String != survivorPhotoKey {
+		orphaned = append(orphaned, &models.MediaAsset{
+			Key:          photoKey.String,
+			Url:          photoUrl.String,
+			ThumbnailUrl: photoThumbnailUrl.String,
+		})
+	}
+
+	return &merge, orphaned, tx.Commit(ctx)
+}
+
+// GetMergedInto returns the actor that the given, merged away actor now
+// lives on.
+func (f *actorRepo) GetMergedInto(ctx context.Context, req *models.ActorPrimarKey) (string, error) {
+
+	var survivorId string
+
+	err := f.db.QueryRow(ctx, "SELECT survivor_actor_id FROM actor_merge WHERE merged_actor_id = $1", req.Id).Scan(&survivorId)
+	if err != nil {
+		return "", err
+	}
+
+	return survivorId, nil
+}
+
+func (f *actorRepo) CreateMany(ctx context.Context, req []*models.CreateActor, mode string) ([]*models.BatchResult, error) {
+
+	var rows = make([][]interface{}, 0, len(req))
+
+	for _, item := range req {
+
+		item.ApplyDefaults()
+
+		rows = append(rows, []interface{}{
+			uuid.New().String(),
+			item.First_name,
+			item.Last_name,
+			nullIfEmpty(item.DisplayName),
+			item.Biography,
+			nullIfEmpty(item.BirthDate),
+			item.Birthplace,
+			item.ExternalIds,
+		})
+	}
+
+	return insertMany(ctx, f.db, "actor",
+		[]string{
+			"actor_id",
+			"first_name",
+			"last_name",
+			"display_name",
+			"biography",
+			"birth_date",
+			"birthplace",
+			"external_ids",
+		},
+		rows,
+		mode,
+	)
+}
+
+func (f *actorRepo) DeleteMany(ctx context.Context, ids []string, mode string) ([]*models.BatchResult, []*models.MediaAsset, error) {
+	return deleteMany(ctx, f.db, "actor", "actor_id", ids, mode, actorMedia)
+}
+
+func scanActor(row rowScanner, prefix ...interface{}) (*models.Actor, error) {
+
+	var (
+		id                sql.NullString
+		first_name        sql.NullString
+		last_name         sql.NullString
+		displayName       sql.NullString
+		biography         sql.NullString
+		birthDate         sql.NullString
+		birthplace        sql.NullString
+		externalIds       map[string]string
+		photoUrl          sql.NullString
+		photoThumbnailUrl sql.NullString
+		createdAt         sql.NullString
+		updatedAt         sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&first_name,
+		&last_name,
+		&displayName,
+		&biography,
+		&birthDate,
+		&birthplace,
+		&externalIds,
+		&photoUrl,
+		&photoThumbnailUrl,
+		&createdAt,
+		&updatedAt,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Actor{
+		Id:                id.String,
+		First_name:        first_name.String,
+		Last_name:         last_name.String,
+		DisplayName:       displayName.String,
+		Biography:         biography.String,
+		BirthDate:         birthDate.String,
+		Birthplace:        birthplace.String,
+		ExternalIds:       externalIds,
+		PhotoUrl:          photoUrl.String,
+		PhotoThumbnailUrl: photoThumbnailUrl.String,
+		CreatedAt:         createdAt.String,
+		UpdatedAt:         updatedAt.String,
+	}, nil
 }
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"crud/models"
+	"crud/storage"
+)
+
+type addressRepo struct {
+	db DB
+}
+
+func NewAddressRepo(db DB) *addressRepo {
+	return &addressRepo{
+		db: db,
+	}
+}
+
+func (f *addressRepo) Create(ctx context.Context, address *models.CreateAddress) (string, error) {
+
+	var (
+		id    = uuid.New().String()
+		query string
+	)
+
+	query = `
+		INSERT INTO address(
+			address_id,
+			address,
+			address2,
+			district,
+			city_id,
+			postal_code,
+			phone,
+			updated_at
+		) VALUES ( $1, $2, $3, $4, $5, $6, $7, now() )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		id,
+		address.Address,
+		nullIfEmpty(address.Address2),
+		address.District,
+		address.CityId,
+		nullIfEmpty(address.PostalCode),
+		address.Phone,
+	)
+
+	if isForeignKeyViolation(err) {
+		return "", storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (f *addressRepo) Update(ctx context.Context, id string, req *models.CreateAddress) (int64, error) {
+
+	query := `
+		UPDATE
+			address
+		SET
+			address = $2,
+			address2 = $3,
+			district = $4,
+			city_id = $5,
+			postal_code = $6,
+			phone = $7,
+			updated_at = now()
+		WHERE address_id = $1
+	`
+
+	rowsAffected, err := f.db.Exec(ctx, query,
+		id,
+		req.Address,
+		nullIfEmpty(req.Address2),
+		req.District,
+		req.CityId,
+		nullIfEmpty(req.PostalCode),
+		req.Phone,
+	)
+
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
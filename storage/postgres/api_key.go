@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"crud/models"
+)
+
+type apiKeyRepo struct {
+	db DB
+}
+
+func NewApiKeyRepo(db DB) *apiKeyRepo {
+	return &apiKeyRepo{
+		db: db,
+	}
+}
+
+func (f *apiKeyRepo) Create(ctx context.Context, apiKey *models.CreateApiKey) (string, error) {
+
+	var (
+		id    = uuid.New().String()
+		query string
+	)
+
+	if apiKey.Scopes == nil {
+		apiKey.Scopes = []string{}
+	}
+
+	query = `
+		INSERT INTO api_key(
+			api_key_id,
+			name,
+			key_prefix,
+			key_hash,
+			scopes,
+			expires_at,
+			updated_at
+		) VALUES ( $1, $2, $3, $4, $5, NULLIF($6, '')::TIMESTAMP, now() )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		id,
+		apiKey.Name,
+		apiKey.KeyPrefix,
+		apiKey.KeyHash,
+		apiKey.Scopes,
+		apiKey.ExpiresAt,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (f *apiKeyRepo) GetByPKey(ctx context.Context, pkey *models.ApiKeyPrimarKey) (*models.ApiKey, error) {
+
+	query := `
+		SELECT
+			api_key_id,
+			name,
+			key_prefix,
+			scopes,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at,
+			updated_at
+		FROM
+			api_key
+		WHERE api_key_id = $1
+	`
+
+	return f.scanApiKey(f.db.QueryRow(ctx, query, pkey.Id))
+}
+
+func (f *apiKeyRepo) GetActiveByHash(ctx context.Context, hash string) (*models.ApiKey, error) {
+
+	query := `
+		SELECT
+			api_key_id,
+			name,
+			key_prefix,
+			scopes,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at,
+			updated_at
+		FROM
+			api_key
+		WHERE key_hash = $1
+			AND revoked_at IS NULL
+			AND (expires_at IS NULL OR expires_at > now())
+	`
+
+	return f.scanApiKey(f.db.QueryRow(ctx, query, hash))
+}
+
+func (f *apiKeyRepo) GetList(ctx context.Context, req *models.GetListApiKeyRequest) (*models.GetListApiKeyResponse, error) {
+
+	var (
+		resp   = models.GetListApiKeyResponse{}
+		offset = " OFFSET 0"
+		limit  = " LIMIT 5"
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	query := `
+		SELECT
+			COUNT(*) OVER(),
+			api_key_id,
+			name,
+			key_prefix,
+			scopes,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at,
+			updated_at
+		FROM
+			api_key
+		ORDER BY created_at DESC
+	`
+
+	query += offset + limit
+
+	rows, err := f.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var count int32
+
+		apiKey, err := f.scanApiKey(rows, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Count = count
+		resp.ApiKeys = append(resp.ApiKeys, apiKey)
+	}
+
+	return &resp, rows.Err()
+}
+
+func (f *apiKeyRepo) Revoke(ctx context.Context, req *models.ApiKeyPrimarKey) (int64, error) {
+
+	query := `
+		UPDATE
+			api_key
+		SET
+			revoked_at = now(),
+			updated_at = now()
+		WHERE api_key_id = $1 AND revoked_at IS NULL
+	`
+
+	rowsAffected, err := f.db.Exec(ctx, query, req.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func (f *apiKeyRepo) TouchLastUsed(ctx context.Context, req *models.ApiKeyPrimarKey) error {
+
+	_, err := f.db.Exec(ctx, "UPDATE api_key SET last_used_at = now() WHERE api_key_id = $1", req.Id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (f *apiKeyRepo) scanApiKey(row rowScanner, prefix ...interface{}) (*models.ApiKey, error) {
+
+	var (
+		id         sql.NullString
+		name       sql.NullString
+		keyPrefix  sql.NullString
+		scopes     []string
+		expiresAt  sql.NullString
+		lastUsedAt sql.NullString
+		revokedAt  sql.NullString
+		createdAt  sql.NullString
+		updatedAt  sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&name,
+		&keyPrefix,
+		&scopes,
+		&expiresAt,
+		&lastUsedAt,
+		&revokedAt,
+		&createdAt,
+		&updatedAt,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ApiKey{
+		Id:         id.String,
+		Name:       name.String,
+		KeyPrefix:  keyPrefix.String,
+		Scopes:     scopes,
+		ExpiresAt:  expiresAt.String,
+		LastUsedAt: lastUsedAt.String,
+		RevokedAt:  revokedAt.String,
+		CreatedAt:  createdAt.String,
+		UpdatedAt:  updatedAt.String,
+	}, nil
+}
@@ -0,0 +1,261 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+// maxQueryParams is the limit of bind parameters in one postgres statement.
+const maxQueryParams = 65535
+
+// insertMany inserts rows in one transaction. The first value of every row
+// must be the primary key, updated_at is set to now() for every row. In
+// all_or_nothing mode rows are sent as multi-row inserts and any failure
+// rolls everything back, in best_effort mode every row runs in its own
+// savepoint. Failed rows are reported per item either way.
+func insertMany(ctx context.Context, db DB, table string, columns []string, rows [][]interface{}, mode string) ([]*models.BatchResult, error) {
+
+	results := make([]*models.BatchResult, len(rows))
+	for i, row := range rows {
+		results[i] = &models.BatchResult{Index: i, Id: row[0].(string)}
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if mode == models.BatchModeBestEffort {
+		for i, row := range rows {
+			err = execSavepoint(ctx, tx, insertQuery(table, columns, 1), row...)
+			if err != nil {
+				message, ok := batchItemError(err, storage.ErrReferenceNotFound)
+				if !ok {
+					return nil, err
+				}
+
+				results[i].Id = ""
+				results[i].Error = message
+			}
+		}
+
+		return results, tx.Commit(ctx)
+	}
+
+	chunk := maxQueryParams / len(columns)
+
+	for start := 0; start < len(rows); start += chunk {
+
+		end := start + chunk
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		var args []interface{}
+		for _, row := range rows[start:end] {
+			args = append(args, row...)
+		}
+
+		err = execSavepoint(ctx, tx, insertQuery(table, columns, end-start), args...)
+		if err == nil {
+			continue
+		}
+
+		// The multi-row insert does not tell which row failed, replay the
+		// chunk row by row to find it. Everything is rolled back anyway.
+		for i := start; i < end; i++ {
+			rowErr := execSavepoint(ctx, tx, insertQuery(table, columns, 1), rows[i]...)
+			if rowErr == nil {
+				continue
+			}
+
+			message, ok := batchItemError(rowErr, storage.ErrReferenceNotFound)
+			if !ok {
+				return nil, rowErr
+			}
+
+			results[i].Error = message
+
+			for _, result := range results {
+				result.Id = ""
+			}
+
+			return rolledBack(results), nil
+		}
+
+		return nil, err
+	}
+
+	return results, tx.Commit(ctx)
+}
+
+// deleteMany deletes rows by primary key, one statement per id, and returns
+// the uploads of the deleted rows described by media. Malformed, missing and
+// still referenced ids are reported as failed items and, in all_or_nothing
+// mode, roll the whole batch back. In best_effort mode every id runs in its
+// own savepoint.
+func deleteMany(ctx context.Context, db DB, table string, idColumn string, ids []string, mode string, media []mediaColumns) ([]*models.BatchResult, []*models.MediaAsset, error) {
+
+	results := make([]*models.BatchResult, len(ids))
+	for i, id := range ids {
+		results[i] = &models.BatchResult{Index: i, Id: id}
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		query  = deleteQuery(table, idColumn, media)
+		assets []*models.MediaAsset
+		failed bool
+	)
+
+	for i, id := range ids {
+
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			results[i].Error = "invalid id"
+			failed = true
+			continue
+		}
+
+		results[i].Id = parsed.String()
+
+		var deleted []*models.MediaAsset
+
+		if mode == models.BatchModeBestEffort {
+			deleted, err = deleteSavepoint(ctx, tx, query, results[i].Id, media)
+		} else {
+			deleted, err = scanDeleted(tx.QueryRow(ctx, query, results[i].Id), media)
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			results[i].Error = "not found"
+			failed = true
+			continue
+		}
+
+		if err != nil {
+			message, ok := batchItemError(err, storage.ErrInUse)
+			if !ok {
+				return nil, nil, err
+			}
+
+			results[i].Error = message
+			failed = true
+
+			// The failed statement aborted the transaction.
+			if mode != models.BatchModeBestEffort {
+				break
+			}
+
+			continue
+		}
+
+		assets = append(assets, deleted...)
+	}
+
+	if failed && mode != models.BatchModeBestEffort {
+		return rolledBack(results), nil, nil
+	}
+
+	return results, assets, tx.Commit(ctx)
+}
+
+func deleteSavepoint(ctx context.Context, tx pgx.Tx, query string, id string, media []mediaColumns) ([]*models.MediaAsset, error) {
+
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer savepoint.Rollback(ctx)
+
+	assets, err := scanDeleted(savepoint.QueryRow(ctx, query, id), media)
+	if err != nil {
+		return nil, err
+	}
+
+	return assets, savepoint.Commit(ctx)
+}
+
+// batchItemError turns the error of one batch item into a stable message for
+// its result. A foreign key violation means the item references a missing row
+// on insert and is still referenced on delete, the caller passes which. Any
+// other data or constraint error is reported as an invalid value. Remaining
+// errors are not the item's fault and are not mapped.
+func batchItemError(err error, foreignKeyErr error) (string, bool) {
+
+	if isUniqueViolation(err) {
+		return storage.ErrAlreadyExists.Error(), true
+	}
+
+	if isForeignKeyViolation(err) {
+		return foreignKeyErr.Error(), true
+	}
+
+	if isDataError(err) {
+		return "invalid value", true
+	}
+
+	return "", false
+}
+
+// rolledBack marks every item that did not fail itself as rolled back, none
+// of them was stored.
+func rolledBack(results []*models.BatchResult) []*models.BatchResult {
+
+	for _, result := range results {
+		if result.Error == "" {
+			result.Error = "rolled back"
+		}
+	}
+
+	return results
+}
+
+func execSavepoint(ctx context.Context, tx pgx.Tx, query string, args ...interface{}) error {
+
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer savepoint.Rollback(ctx)
+
+	_, err = savepoint.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return savepoint.Commit(ctx)
+}
+
+func insertQuery(table string, columns []string, rows int) string {
+
+	var (
+		values = make([]string, rows)
+		n      = 1
+	)
+
+	for i := range values {
+		params := make([]string, len(columns))
+		for j := range params {
+			params[j] = fmt.Sprintf("$%d", n)
+			n++
+		}
+		values[i] = "(" + strings.Join(params, ", ") + ", now())"
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s, updated_at) VALUES %s", table, strings.Join(columns, ", "), strings.Join(values, ", "))
+}
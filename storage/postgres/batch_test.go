@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+)
+
+const malformedId = "not-a-uuid"
+
+// fakeTx answers like postgres would for uuid columns: a statement with a
+// malformed uuid argument fails with invalid_text_representation. Savepoints
+// are fake transactions of their own. Methods not overridden panic.
+type fakeTx struct {
+	pgx.Tx
+	committed bool
+}
+
+func (f *fakeTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	return &fakeTx{}, nil
+}
+
+func (f *fakeTx) Commit(ctx context.Context) error {
+	f.committed = true
+	return nil
+}
+
+func (f *fakeTx) Rollback(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+
+	for _, arg := range args {
+		if arg == malformedId {
+			return nil, &pgconn.PgError{Code: "22P02", Message: "invalid input syntax for type uuid"}
+		}
+	}
+
+	return pgconn.CommandTag("INSERT 0 1"), nil
+}
+
+func (f *fakeTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return fakeRow{id: args[0].(string)}
+}
+
+type fakeRow struct {
+	id string
+}
+
+func (f fakeRow) Scan(dest ...interface{}) error {
+	*dest[0].(*string) = f.id
+	return nil
+}
+
+type fakeDB struct {
+	DB
+	tx *fakeTx
+}
+
+func (f *fakeDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	return f.tx, nil
+}
+
+func TestInsertManyMalformedValue(t *testing.T) {
+
+	var (
+		columns = []string{"category_id", "name", "parent_id"}
+		rows    = [][]interface{}{
+			{"5f0a3c2e-8f8b-4a8e-9d0e-4b6f1c7a1b01", "Action", nil},
+			{"5f0a3c2e-8f8b-4a8e-9d0e-4b6f1c7a1b02", "Drama", malformedId},
+			{"5f0a3c2e-8f8b-4a8e-9d0e-4b6f1c7a1b03", "Comedy", nil},
+		}
+	)
+
+	tests := []struct {
+		mode          string
+		wantIds       []string
+		wantErrors    []string
+		wantCommitted bool
+	}{
+		{
+			mode:          models.BatchModeBestEffort,
+			wantIds:       []string{rows[0][0].(string), "", rows[2][0].(string)},
+			wantErrors:    []string{"", "invalid value", ""},
+			wantCommitted: true,
+		},
+		{
+			mode:       models.BatchModeAllOrNothing,
+			wantIds:    []string{"", "", ""},
+			wantErrors: []string{"rolled back", "invalid value", "rolled back"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+
+			db := &fakeDB{tx: &fakeTx{}}
+
+			results, err := insertMany(context.Background(), db, "category", columns, rows, tt.mode)
+			if err != nil {
+				t.Fatalf("insertMany: %v", err)
+			}
+
+			for i, result := range results {
+				if result.Id != tt.wantIds[i] || result.Error != tt.wantErrors[i] {
+					t.Errorf("item %d = {%q, %q}, want {%q, %q}", i, result.Id, result.Error, tt.wantIds[i], tt.wantErrors[i])
+				}
+			}
+
+			if db.tx.committed != tt.wantCommitted {
+				t.Errorf("committed = %v, want %v", db.tx.committed, tt.wantCommitted)
+			}
+		})
+	}
+}
+
+func TestDeleteManyMalformedId(t *testing.T) {
+
+	ids := []string{
+		"5f0a3c2e-8f8b-4a8e-9d0e-4b6f1c7a1b01",
+		malformedId,
+		"5f0a3c2e-8f8b-4a8e-9d0e-4b6f1c7a1b03",
+	}
+
+	tests := []struct {
+		mode       string
+		wantErrors []string
+	}{
+		{models.BatchModeBestEffort, []string{"", "invalid id", ""}},
+		{models.BatchModeAllOrNothing, []string{"rolled back", "invalid id", "rolled back"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+
+			db := &fakeDB{tx: &fakeTx{}}
+
+			results, _, err := deleteMany(context.Background(), db, "category", "category_id", ids, tt.mode, nil)
+			if err != nil {
+				t.Fatalf("deleteMany: %v", err)
+			}
+
+			for i, result := range results {
+				if result.Error != tt.wantErrors[i] {
+					t.Errorf("item %d error = %q, want %q", i, result.Error, tt.wantErrors[i])
+				}
+			}
+		})
+	}
+}
@@ -6,17 +6,18 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/jackc/pgx/v4"
 
 	"crud/models"
 	"crud/pkg/helper"
+	"crud/storage"
 )
 
 type categoryRepo struct {
-	db *pgxpool.Pool
+	db DB
 }
 
-func NewCategoryRepo(db *pgxpool.Pool) *categoryRepo {
+func NewCategoryRepo(db DB) *categoryRepo {
 	return &categoryRepo{
 		db: db,
 	}
@@ -33,15 +34,25 @@ func (f *categoryRepo) Create(ctx context.Context, category *models.CreateCatego
 		INSERT INTO category(
 			category_id,
 			name,
+			parent_id,
 			updated_at
-		) VALUES ( $1, $2, now() )
+		) VALUES ( $1, $2, $3, now() )
 	`
 
 	_, err := f.db.Exec(ctx, query,
 		id,
 		category.Name,
+		nullIfEmpty(category.ParentId),
 	)
 
+	if isUniqueViolation(err) {
+		return "", storage.ErrAlreadyExists
+	}
+
+	if isForeignKeyViolation(err) {
+		return "", storage.ErrReferenceNotFound
+	}
+
 	if err != nil {
 		return "", err
 	}
@@ -49,44 +60,25 @@ func (f *categoryRepo) Create(ctx context.Context, category *models.CreateCatego
 	return id, nil
 }
 
-func (f *categoryRepo) GetByPKey(ctx context.Context, pkey *models.CategoryPrimarKey) (*models.Category, error) {
+// categoryColumns is shared by every query that returns whole categories,
+// the category table has to be aliased as c.
+const categoryColumns = `
+			c.category_id,
+			c.name,
+			c.parent_id,
+			c.created_at,
+			c.updated_at
+`
 
-	var (
-		id        sql.NullString
-		name      sql.NullString
-		createdAt sql.NullString
-		updatedAt sql.NullString
-	)
+func (f *categoryRepo) GetByPKey(ctx context.Context, pkey *models.CategoryPrimarKey) (*models.Category, error) {
 
-	query := `
-		SELECT
-			category_id,
-			name,
-			created_at,
-			updated_at
+	query := `SELECT` + categoryColumns + `
 		FROM
-			category
-		WHERE category_id = $1
+			category c
+		WHERE c.category_id = $1
 	`
 
-	err := f.db.QueryRow(ctx, query, pkey.Id).
-		Scan(
-			&id,
-			&name,
-			&createdAt,
-			&updatedAt,
-		)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return &models.Category{
-		Id:        id.String,
-		Name:      name.String,
-		CreatedAt: createdAt.String,
-		UpdatedAt: updatedAt.String,
-	}, nil
+	return scanCategory(f.db.QueryRow(ctx, query, pkey.Id))
 }
 
 func (f *categoryRepo) GetList(ctx context.Context, req *models.GetListCategoryRequest) (*models.GetListCategoryResponse, error) {
@@ -105,54 +97,75 @@ func (f *categoryRepo) GetList(ctx context.Context, req *models.GetListCategoryR
 		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
 	}
 
-	query := `
-		SELECT
-			COUNT(*) OVER(),
-			category_id,
-			name,
-			created_at,
-			updated_at
+	query := `SELECT COUNT(*) OVER(),` + categoryColumns + `
 		FROM
-			category
+			category c
 	`
 
 	query += offset + limit
 
 	rows, err := f.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 
-		var (
-			id        sql.NullString
-			name      sql.NullString
-			createdAt sql.NullString
-			updatedAt sql.NullString
-		)
-
-		err := rows.Scan(
-			&resp.Count,
-			&id,
-			&name,
-			&createdAt,
-			&updatedAt,
-		)
-
+		category, err := scanCategory(rows, &resp.Count)
 		if err != nil {
 			return nil, err
 		}
 
-		resp.Categorys = append(resp.Categorys, &models.Category{
-			Id:        id.String,
-			Name:      name.String,
-			CreatedAt: createdAt.String,
-			UpdatedAt: updatedAt.String,
-		})
+		resp.Categorys = append(resp.Categorys, category)
+	}
+
+	return &resp, rows.Err()
+}
+
+// Export streams every category matching the list request to fn row by row
+// instead of collecting them like GetList. Limit and offset are optional.
+func (f *categoryRepo) Export(ctx context.Context, req *models.GetListCategoryRequest, fn func(*models.Category) error) error {
+
+	query := `SELECT` + categoryColumns + `
+		FROM
+			category c
+		ORDER BY c.created_at
+	`
+
+	if req.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	if req.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	rows, err := f.db.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
+	for rows.Next() {
+
+		category, err := scanCategory(rows)
+		if err != nil {
+			return err
+		}
+
+		err = fn(category)
+		if err != nil {
+			return err
+		}
 	}
 
-	return &resp, err
+	return rows.Err()
 }
 
+// Update renames and moves the category. It fails with
+// storage.ErrCategoryCycle when the new parent is the category itself or
+// one of its descendants.
 func (f *categoryRepo) Update(ctx context.Context, id string, req *models.UpdateCategory) (int64, error) {
 
 	var (
@@ -165,31 +178,336 @@ func (f *categoryRepo) Update(ctx context.Context, id string, req *models.Update
 			category
 		SET
 			name = :name,
+			parent_id = :parent_id,
 			updated_at = now()
 		WHERE category_id = :category_id
 	`
 
 	params = map[string]interface{}{
-		"actor_id": id,
-		"name":     req.Name,
+		"category_id": id,
+		"name":        req.Name,
+		"parent_id":   nullIfEmpty(req.ParentId),
 	}
 
 	query, args := helper.ReplaceQueryParams(query, params)
 
-	rowsAffected, err := f.db.Exec(ctx, query, args...)
+	tx, err := f.db.Begin(ctx)
 	if err != nil {
 		return 0, err
 	}
+	defer tx.Rollback(ctx)
+
+	err = lockCategoryTree(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := tx.Exec(ctx, query, args...)
+	if isUniqueViolation(err) {
+		return 0, storage.ErrAlreadyExists
+	}
+
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if rowsAffected.RowsAffected() == 0 {
+		return 0, nil
+	}
 
-	return rowsAffected.RowsAffected(), nil
+	err = checkCategoryCycle(ctx, tx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), tx.Commit(ctx)
 }
 
+// Delete fails with storage.ErrInUse while the category has subcategories.
 func (f *categoryRepo) Delete(ctx context.Context, req *models.CategoryPrimarKey) error {
 
 	_, err := f.db.Exec(ctx, "DELETE FROM category WHERE category_id = $1", req.Id)
+	if isForeignKeyViolation(err) {
+		return storage.ErrInUse
+	}
+
+	return err
+}
+
+// Upsert inserts the category or updates the existing row with the same natural
+// key, moving it under the given parent. It reports whether a new row was
+// created.
+func (f *categoryRepo) Upsert(ctx context.Context, category *models.CreateCategory) (string, bool, error) {
+
+	var (
+		id      string
+		created bool
+	)
+
+	query := `
+		INSERT INTO category(
+			category_id,
+			name,
+			parent_id,
+			updated_at
+		) VALUES ( $1, $2, $3, now() )
+		ON CONFLICT (name) DO UPDATE SET
+			parent_id = EXCLUDED.parent_id,
+			updated_at = now()
+		RETURNING category_id, xmax = 0
+	`
+
+	tx, err := f.db.Begin(ctx)
 	if err != nil {
-		return err
+		return "", false, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = lockCategoryTree(ctx, tx)
+	if err != nil {
+		return "", false, err
+	}
+
+	err = tx.QueryRow(ctx, query,
+		uuid.New().String(),
+		category.Name,
+		nullIfEmpty(category.ParentId),
+	).Scan(&id, &created)
+
+	if isForeignKeyViolation(err) {
+		return "", false, storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	err = checkCategoryCycle(ctx, tx, id)
+	if err != nil {
+		return "", false, err
 	}
 
+	return id, created, tx.Commit(ctx)
+}
+
+// lockCategoryTree serializes the transactions that move categories, two
+// moves that are fine on their own could form a cycle together.
+func lockCategoryTree(ctx context.Context, tx pgx.Tx) error {
+
+	_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('category.parent_id'))")
+
 	return err
 }
+
+// checkCategoryCycle fails with storage.ErrCategoryCycle when the category
+// is among its own ancestors after it was moved.
+func checkCategoryCycle(ctx context.Context, tx pgx.Tx, id string) error {
+
+	var cycle bool
+
+	err := tx.QueryRow(ctx, `
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_id FROM category WHERE category_id = $1
+			UNION
+			SELECT c.parent_id FROM category c JOIN ancestors a ON c.category_id = a.parent_id
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE parent_id = $1)
+	`, id).Scan(&cycle)
+
+	if err != nil {
+		return err
+	}
+
+	if cycle {
+		return storage.ErrCategoryCycle
+	}
+
+	return nil
+}
+
+// GetTree returns every category nested under its parent, siblings ordered
+// by name.
+func (f *categoryRepo) GetTree(ctx context.Context) (*models.GetCategoryTreeResponse, error) {
+
+	var (
+		resp  = models.GetCategoryTreeResponse{Categories: []*models.CategoryNode{}}
+		nodes = map[string]*models.CategoryNode{}
+	)
+
+	// Ordering by the path of names puts every parent before its children.
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT
+				category_id,
+				ARRAY[name::TEXT] AS path
+			FROM
+				category
+			WHERE parent_id IS NULL
+			UNION ALL
+			SELECT
+				c.category_id,
+				t.path || c.name::TEXT
+			FROM
+				category c
+			JOIN tree t ON c.parent_id = t.category_id
+		)
+		SELECT` + categoryColumns + `
+		FROM
+			tree t
+		JOIN category c ON c.category_id = t.category_id
+		ORDER BY t.path
+	`
+
+	rows, err := f.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		node := &models.CategoryNode{
+			Category: *category,
+			Children: []*models.CategoryNode{},
+		}
+
+		nodes[node.Id] = node
+		resp.Count++
+
+		parent, ok := nodes[node.ParentId]
+		if !ok {
+			resp.Categories = append(resp.Categories, node)
+			continue
+		}
+
+		parent.Children = append(parent.Children, node)
+	}
+
+	return &resp, rows.Err()
+}
+
+// GetDescendants returns the subcategories of the category at every depth,
+// each one after its parent.
+func (f *categoryRepo) GetDescendants(ctx context.Context, req *models.CategoryPrimarKey) (*models.GetCategoryDescendantsResponse, error) {
+
+	var resp = models.GetCategoryDescendantsResponse{Categories: []*models.CategoryDescendant{}}
+
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT
+				category_id,
+				1 AS depth,
+				ARRAY[name::TEXT] AS path
+			FROM
+				category
+			WHERE parent_id = $1
+			UNION ALL
+			SELECT
+				c.category_id,
+				t.depth + 1,
+				t.path || c.name::TEXT
+			FROM
+				category c
+			JOIN tree t ON c.parent_id = t.category_id
+		)
+		SELECT
+			t.depth,` + categoryColumns + `
+		FROM
+			tree t
+		JOIN category c ON c.category_id = t.category_id
+		ORDER BY t.path
+	`
+
+	rows, err := f.db.Query(ctx, query, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var descendant models.CategoryDescendant
+
+		category, err := scanCategory(rows, &descendant.Depth)
+		if err != nil {
+			return nil, err
+		}
+
+		descendant.Category = *category
+		resp.Categories = append(resp.Categories, &descendant)
+	}
+
+	resp.Count = int32(len(resp.Categories))
+
+	return &resp, rows.Err()
+}
+
+func (f *categoryRepo) CreateMany(ctx context.Context, req []*models.CreateCategory, mode string) ([]*models.BatchResult, error) {
+
+	var rows = make([][]interface{}, 0, len(req))
+
+	for _, item := range req {
+		rows = append(rows, []interface{}{
+			uuid.New().String(),
+			item.Name,
+			nullIfEmpty(item.ParentId),
+		})
+	}
+
+	return insertMany(ctx, f.db, "category",
+		[]string{
+			"category_id",
+			"name",
+			"parent_id",
+		},
+		rows,
+		mode,
+	)
+}
+
+func (f *categoryRepo) DeleteMany(ctx context.Context, ids []string, mode string) ([]*models.BatchResult, error) {
+	results, _, err := deleteMany(ctx, f.db, "category", "category_id", ids, mode, nil)
+
+	return results, err
+}
+
+func scanCategory(row rowScanner, prefix ...interface{}) (*models.Category, error) {
+
+	var (
+		id        sql.NullString
+		name      sql.NullString
+		parentId  sql.NullString
+		createdAt sql.NullString
+		updatedAt sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&name,
+		&parentId,
+		&createdAt,
+		&updatedAt,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Category{
+		Id:        id.String,
+		Name:      name.String,
+		ParentId:  parentId.String,
+		CreatedAt: createdAt.String,
+		UpdatedAt: updatedAt.String,
+	}, nil
+}
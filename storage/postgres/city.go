@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"crud/models"
+	"crud/storage"
+)
+
+type cityRepo struct {
+	db DB
+}
+
+func NewCityRepo(db DB) *cityRepo {
+	return &cityRepo{
+		db: db,
+	}
+}
+
+func (f *cityRepo) Create(ctx context.Context, city *models.CreateCity) (string, error) {
+
+	var (
+		id    = uuid.New().String()
+		query string
+	)
+
+	query = `
+		INSERT INTO city(
+			city_id,
+			name,
+			country_id,
+			updated_at
+		) VALUES ( $1, $2, $3, now() )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		id,
+		city.Name,
+		city.CountryId,
+	)
+
+	if isUniqueViolation(err) {
+		return "", storage.ErrAlreadyExists
+	}
+
+	if isForeignKeyViolation(err) {
+		return "", storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+const (
+	cityColumns = `
+			ci.city_id,
+			ci.name,
+			ci.created_at,
+			ci.updated_at,
+			co.country_id,
+			co.name,
+			co.created_at,
+			co.updated_at
+	`
+
+	cityFrom = `
+		FROM
+			city ci
+		JOIN country co ON co.country_id = ci.country_id
+	`
+)
+
+func (f *cityRepo) GetByPKey(ctx context.Context, pkey *models.CityPrimarKey) (*models.City, error) {
+
+	query := `SELECT` + cityColumns + cityFrom + `
+		WHERE ci.city_id = $1
+	`
+
+	return scanCity(f.db.QueryRow(ctx, query, pkey.Id))
+}
+
+func (f *cityRepo) GetList(ctx context.Context, req *models.GetListCityRequest) (*models.GetListCityResponse, error) {
+
+	var (
+		resp   = models.GetListCityResponse{}
+		offset = " OFFSET 0"
+		limit  = " LIMIT 5"
+		where  string
+		args   []interface{}
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	if req.CountryId != "" {
+		where = " WHERE ci.country_id = $1"
+		args = append(args, req.CountryId)
+	}
+
+	query := `SELECT COUNT(*) OVER(),` + cityColumns + cityFrom + where + `
+		ORDER BY ci.name
+	`
+
+	query += offset + limit
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		city, err := scanCity(rows, &resp.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Cities = append(resp.Cities, city)
+	}
+
+	return &resp, rows.Err()
+}
+
+func (f *cityRepo) Update(ctx context.Context, id string, req *models.UpdateCity) (int64, error) {
+
+	query := `
+		UPDATE
+			city
+		SET
+			name = $2,
+			country_id = $3,
+			updated_at = now()
+		WHERE city_id = $1
+	`
+
+	rowsAffected, err := f.db.Exec(ctx, query, id, req.Name, req.CountryId)
+	if isUniqueViolation(err) {
+		return 0, storage.ErrAlreadyExists
+	}
+
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func (f *cityRepo) Delete(ctx context.Context, req *models.CityPrimarKey) (int64, error) {
+
+	rowsAffected, err := f.db.Exec(ctx, "DELETE FROM city WHERE city_id = $1", req.Id)
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrInUse
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func scanCity(row rowScanner, prefix ...interface{}) (*models.City, error) {
+
+	var (
+		id               sql.NullString
+		name             sql.NullString
+		createdAt        sql.NullString
+		updatedAt        sql.NullString
+		countryId        sql.NullString
+		countryName      sql.NullString
+		countryCreatedAt sql.NullString
+		countryUpdatedAt sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&name,
+		&createdAt,
+		&updatedAt,
+		&countryId,
+		&countryName,
+		&countryCreatedAt,
+		&countryUpdatedAt,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.City{
+		Id:   id.String,
+		Name: name.String,
+		Country: &models.Country{
+			Id:        countryId.String,
+			Name:      countryName.String,
+			CreatedAt: countryCreatedAt.String,
+			UpdatedAt: countryUpdatedAt.String,
+		},
+		CreatedAt: createdAt.String,
+		UpdatedAt: updatedAt.String,
+	}, nil
+}
@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"crud/models"
+	"crud/pkg/helper"
+	"crud/storage"
+)
+
+type countryRepo struct {
+	db DB
+}
+
+func NewCountryRepo(db DB) *countryRepo {
+	return &countryRepo{
+		db: db,
+	}
+}
+
+func (f *countryRepo) Create(ctx context.Context, country *models.CreateCountry) (string, error) {
+
+	var (
+		id    = uuid.New().String()
+		query string
+	)
+
+	query = `
+		INSERT INTO country(
+			country_id,
+			name,
+			updated_at
+		) VALUES ( $1, $2, now() )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		id,
+		country.Name,
+	)
+
+	if isUniqueViolation(err) {
+		return "", storage.ErrAlreadyExists
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (f *countryRepo) GetByPKey(ctx context.Context, pkey *models.CountryPrimarKey) (*models.Country, error) {
+
+	var (
+		id        sql.NullString
+		name      sql.NullString
+		createdAt sql.NullString
+		updatedAt sql.NullString
+	)
+
+	query := `
+		SELECT
+			country_id,
+			name,
+			created_at,
+			updated_at
+		FROM
+			country
+		WHERE country_id = $1
+	`
+
+	err := f.db.QueryRow(ctx, query, pkey.Id).
+		Scan(
+			&id,
+			&name,
+			&createdAt,
+			&updatedAt,
+		)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Country{
+		Id:        id.String,
+		Name:      name.String,
+		CreatedAt: createdAt.String,
+		UpdatedAt: updatedAt.String,
+	}, nil
+}
+
+func (f *countryRepo) GetList(ctx context.Context, req *models.GetListCountryRequest) (*models.GetListCountryResponse, error) {
+
+	var (
+		resp   = models.GetListCountryResponse{}
+		offset = " OFFSET 0"
+		limit  = " LIMIT 5"
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	query := `
+		SELECT
+			COUNT(*) OVER(),
+			country_id,
+			name,
+			created_at,
+			updated_at
+		FROM
+			country
+		ORDER BY name
+	`
+
+	query += offset + limit
+
+	rows, err := f.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var (
+			id        sql.NullString
+			name      sql.NullString
+			createdAt sql.NullString
+			updatedAt sql.NullString
+		)
+
+		err := rows.Scan(
+			&resp.Count,
+			&id,
+			&name,
+			&createdAt,
+			&updatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Countries = append(resp.Countries, &models.Country{
+			Id:        id.String,
+			Name:      name.String,
+			CreatedAt: createdAt.String,
+			UpdatedAt: updatedAt.String,
+		})
+
+	}
+
+	return &resp, rows.Err()
+}
+
+func (f *countryRepo) Update(ctx context.Context, id string, req *models.UpdateCountry) (int64, error) {
+
+	var (
+		query  = ""
+		params map[string]interface{}
+	)
+
+	query = `
+		UPDATE
+			country
+		SET
+			name = :name,
+			updated_at = now()
+		WHERE country_id = :country_id
+	`
+
+	params = map[string]interface{}{
+		"country_id": id,
+		"name":       req.Name,
+	}
+
+	query, args := helper.ReplaceQueryParams(query, params)
+
+	rowsAffected, err := f.db.Exec(ctx, query, args...)
+	if isUniqueViolation(err) {
+		return 0, storage.ErrAlreadyExists
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func (f *countryRepo) Delete(ctx context.Context, req *models.CountryPrimarKey) (int64, error) {
+
+	rowsAffected, err := f.db.Exec(ctx, "DELETE FROM country WHERE country_id = $1", req.Id)
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrInUse
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
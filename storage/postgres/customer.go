@@ -0,0 +1,333 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"crud/models"
+	"crud/storage"
+)
+
+type customerRepo struct {
+	db DB
+}
+
+func NewCustomerRepo(db DB) *customerRepo {
+	return &customerRepo{
+		db: db,
+	}
+}
+
+// Create inserts the customer for an address created beforehand, see
+// models.CreateCustomer.AddressId.
+func (f *customerRepo) Create(ctx context.Context, customer *models.CreateCustomer) (string, error) {
+
+	var (
+		id     = uuid.New().String()
+		active = true
+		query  string
+	)
+
+	if customer.Active != nil {
+		active = *customer.Active
+	}
+
+	query = `
+		INSERT INTO customer(
+			customer_id,
+			store_id,
+			first_name,
+			last_name,
+			email,
+			address_id,
+			active,
+			updated_at
+		) VALUES ( $1, $2, $3, $4, LOWER($5), $6, $7, now() )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		id,
+		customer.StoreId,
+		customer.FirstName,
+		customer.LastName,
+		customer.Email,
+		customer.AddressId,
+		active,
+	)
+
+	if isUniqueViolation(err) {
+		return "", storage.ErrAlreadyExists
+	}
+
+	if isForeignKeyViolation(err) {
+		return "", storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// customerColumns and customerFrom expand the address, city and country of
+// the customer.
+const (
+	customerColumns = `
+			cu.customer_id,
+			cu.store_id,
+			cu.first_name,
+			cu.last_name,
+			cu.email,
+			cu.active,
+			cu.created_at,
+			cu.updated_at,
+			a.address_id,
+			a.address,
+			a.address2,
+			a.district,
+			a.postal_code,
+			a.phone,
+			a.created_at,
+			a.updated_at,
+	` + cityColumns
+
+	customerFrom = `
+		FROM
+			customer cu
+		JOIN address a ON a.address_id = cu.address_id
+		JOIN city ci ON ci.city_id = a.city_id
+		JOIN country co ON co.country_id = ci.country_id
+	`
+)
+
+func (f *customerRepo) GetByPKey(ctx context.Context, pkey *models.CustomerPrimarKey) (*models.Customer, error) {
+
+	query := `SELECT` + customerColumns + customerFrom + `
+		WHERE cu.customer_id = $1
+	`
+
+	return scanCustomer(f.db.QueryRow(ctx, query, pkey.Id))
+}
+
+func (f *customerRepo) GetList(ctx context.Context, req *models.GetListCustomerRequest) (*models.GetListCustomerResponse, error) {
+
+	var (
+		resp       = models.GetListCustomerResponse{}
+		offset     = " OFFSET 0"
+		limit      = " LIMIT 5"
+		conditions []string
+		args       []interface{}
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if req.StoreId != "" {
+		add("cu.store_id = $%d", req.StoreId)
+	}
+
+	if req.CityId != "" {
+		add("a.city_id = $%d", req.CityId)
+	}
+
+	if req.Active != nil {
+		add("cu.active = $%d", *req.Active)
+	}
+
+	query := `SELECT COUNT(*) OVER(),` + customerColumns + customerFrom
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY cu.last_name, cu.first_name" + offset + limit
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		customer, err := scanCustomer(rows, &resp.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Customers = append(resp.Customers, customer)
+	}
+
+	return &resp, rows.Err()
+}
+
+func (f *customerRepo) Update(ctx context.Context, id string, req *models.UpdateCustomer) (int64, error) {
+
+	query := `
+		UPDATE
+			customer
+		SET
+			store_id = $2,
+			first_name = $3,
+			last_name = $4,
+			email = LOWER($5),
+			active = COALESCE($6, active),
+			updated_at = now()
+		WHERE customer_id = $1
+	`
+
+	rowsAffected, err := f.db.Exec(ctx, query,
+		id,
+		req.StoreId,
+		req.FirstName,
+		req.LastName,
+		req.Email,
+		req.Active,
+	)
+
+	if isUniqueViolation(err) {
+		return 0, storage.ErrAlreadyExists
+	}
+
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+// Delete removes the customer together with its address.
+func (f *customerRepo) Delete(ctx context.Context, req *models.CustomerPrimarKey) (int64, error) {
+
+	query := `
+		WITH deleted AS (
+			DELETE FROM customer WHERE customer_id = $1 RETURNING address_id
+		)
+		DELETE FROM address WHERE address_id IN (SELECT address_id FROM deleted)
+	`
+
+	rowsAffected, err := f.db.Exec(ctx, query, req.Id)
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrInUse
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func scanCustomer(row rowScanner, prefix ...interface{}) (*models.Customer, error) {
+
+	var (
+		id               sql.NullString
+		storeId          sql.NullString
+		firstName        sql.NullString
+		lastName         sql.NullString
+		email            sql.NullString
+		active           sql.NullBool
+		createdAt        sql.NullString
+		updatedAt        sql.NullString
+		addressId        sql.NullString
+		address          sql.NullString
+		address2         sql.NullString
+		district         sql.NullString
+		postalCode       sql.NullString
+		phone            sql.NullString
+		addressCreatedAt sql.NullString
+		addressUpdatedAt sql.NullString
+		cityId           sql.NullString
+		cityName         sql.NullString
+		cityCreatedAt    sql.NullString
+		cityUpdatedAt    sql.NullString
+		countryId        sql.NullString
+		countryName      sql.NullString
+		countryCreatedAt sql.NullString
+		countryUpdatedAt sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&storeId,
+		&firstName,
+		&lastName,
+		&email,
+		&active,
+		&createdAt,
+		&updatedAt,
+		&addressId,
+		&address,
+		&address2,
+		&district,
+		&postalCode,
+		&phone,
+		&addressCreatedAt,
+		&addressUpdatedAt,
+		&cityId,
+		&cityName,
+		&cityCreatedAt,
+		&cityUpdatedAt,
+		&countryId,
+		&countryName,
+		&countryCreatedAt,
+		&countryUpdatedAt,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Customer{
+		Id:        id.String,
+		StoreId:   storeId.String,
+		FirstName: firstName.String,
+		LastName:  lastName.String,
+		Email:     email.String,
+		Active:    active.Bool,
+		Address: &models.Address{
+			Id:         addressId.String,
+			Address:    address.String,
+			Address2:   address2.String,
+			District:   district.String,
+			PostalCode: postalCode.String,
+			Phone:      phone.String,
+			City: &models.City{
+				Id:   cityId.String,
+				Name: cityName.String,
+				Country: &models.Country{
+					Id:        countryId.String,
+					Name:      countryName.String,
+					CreatedAt: countryCreatedAt.String,
+					UpdatedAt: countryUpdatedAt.String,
+				},
+				CreatedAt: cityCreatedAt.String,
+				UpdatedAt: cityUpdatedAt.String,
+			},
+			CreatedAt: addressCreatedAt.String,
+			UpdatedAt: addressUpdatedAt.String,
+		},
+		CreatedAt: createdAt.String,
+		UpdatedAt: updatedAt.String,
+	}, nil
+}
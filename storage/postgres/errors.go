@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgconn"
+)
+
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+func isUniqueViolation(err error) bool {
+
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+func isForeignKeyViolation(err error) bool {
+
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation
+}
+
+// isDataError reports whether err is a data exception (SQLSTATE class 22),
+// e.g. a malformed uuid, or an integrity constraint violation (class 23),
+// both caused by the values sent rather than by the database.
+func isDataError(err error) bool {
+
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23"))
+}
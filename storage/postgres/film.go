@@ -3,20 +3,24 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
 
 	"crud/models"
 	"crud/pkg/helper"
+	"crud/storage"
 )
 
 type filmRepo struct {
-	db *pgxpool.Pool
+	db DB
 }
 
-func NewFilmRepo(db *pgxpool.Pool) *filmRepo {
+func NewFilmRepo(db DB) *filmRepo {
 	return &filmRepo{
 		db: db,
 	}
@@ -24,6 +28,8 @@ func NewFilmRepo(db *pgxpool.Pool) *filmRepo {
 
 func (f *filmRepo) Create(ctx context.Context, film *models.CreateFilm) (string, error) {
 
+	film.ApplyDefaults()
+
 	var (
 		id    = uuid.New().String()
 		query string
@@ -36,8 +42,15 @@ func (f *filmRepo) Create(ctx context.Context, film *models.CreateFilm) (string,
 			description,
 			release_year,
 			duration,
+			language_id,
+			original_language_id,
+			rating,
+			rental_duration,
+			rental_rate,
+			replacement_cost,
+			special_features,
 			updated_at
-		) VALUES ( $1, $2, $3, $4, $5, now() )
+		) VALUES ( $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, now() )
 	`
 
 	_, err := f.db.Exec(ctx, query,
@@ -46,8 +59,23 @@ func (f *filmRepo) Create(ctx context.Context, film *models.CreateFilm) (string,
 		film.Description,
 		film.ReleaseYear,
 		film.Duration,
+		nullIfEmpty(film.LanguageId),
+		nullIfEmpty(film.OriginalLanguageId),
+		film.Rating,
+		film.RentalDuration,
+		film.RentalRate,
+		film.ReplacementCost,
+		film.SpecialFeatures,
 	)
 
+	if isUniqueViolation(err) {
+		return "", storage.ErrAlreadyExists
+	}
+
+	if isForeignKeyViolation(err) {
+		return "", storage.ErrReferenceNotFound
+	}
+
 	if err != nil {
 		return "", err
 	}
@@ -55,56 +83,52 @@ func (f *filmRepo) Create(ctx context.Context, film *models.CreateFilm) (string,
 	return id, nil
 }
 
-func (f *filmRepo) GetByPKey(ctx context.Context, pkey *models.FilmPrimarKey) (*models.Film, error) {
-
-	var (
-		id          sql.NullString
-		title       sql.NullString
-		description sql.NullString
-		releaseYear sql.NullString
-		duration    sql.NullInt32
-		createdAt   sql.NullString
-		updatedAt   sql.NullString
-	)
+// filmColumns and filmFrom are shared by every query that returns whole films
+// so that the languages are expanded the same way everywhere.
+const (
+	filmColumns = `
+			f.film_id,
+			f.title,
+			f.description,
+			TO_CHAR(f.release_year, 'YYYY-MM-DD'),
+			f.duration,
+			l.language_id,
+			l.name,
+			l.created_at,
+			l.updated_at,
+			ol.language_id,
+			ol.name,
+			ol.created_at,
+			ol.updated_at,
+			f.rating,
+			f.rental_duration,
+			f.rental_rate,
+			f.replacement_cost,
+			f.special_features,
+			f.average_rating,
+			f.rating_count,
+			f.poster_url,
+			f.poster_thumbnail_url,
+			f.trailer_url,
+			f.created_at,
+			f.updated_at
+	`
 
-	query := `
-		SELECT
-			film_id,
-			title,
-			description,
-			TO_CHAR(release_year, 'YYYY-MM-DD'),
-			duration,
-			created_at,
-			updated_at
+	filmFrom = `
 		FROM
-			film
-		WHERE film_id = $1
+			film f
+		LEFT JOIN language l ON l.language_id = f.language_id
+		LEFT JOIN language ol ON ol.language_id = f.original_language_id
 	`
+)
 
-	err := f.db.QueryRow(ctx, query, pkey.Id).
-		Scan(
-			&id,
-			&title,
-			&description,
-			&releaseYear,
-			&duration,
-			&createdAt,
-			&updatedAt,
-		)
+func (f *filmRepo) GetByPKey(ctx context.Context, pkey *models.FilmPrimarKey) (*models.Film, error) {
 
-	if err != nil {
-		return nil, err
-	}
+	query := `SELECT` + filmColumns + filmFrom + `
+		WHERE f.film_id = $1
+	`
 
-	return &models.Film{
-		Id:          id.String,
-		Title:       title.String,
-		Description: description.String,
-		ReleaseYear: releaseYear.String,
-		Duration:    duration.Int32,
-		CreatedAt:   createdAt.String,
-		UpdatedAt:   updatedAt.String,
-	}, nil
+	return scanFilm(f.db.QueryRow(ctx, query, pkey.Id))
 }
 
 func (f *filmRepo) GetList(ctx context.Context, req *models.GetListFilmRequest) (*models.GetListFilmResponse, error) {
@@ -123,68 +147,239 @@ func (f *filmRepo) GetList(ctx context.Context, req *models.GetListFilmRequest)
 		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
 	}
 
-	query := `
-		SELECT
-			COUNT(*) OVER(),
-			film_id,
-			title,
-			description,
-			TO_CHAR(release_year, 'YYYY-MM-DD'),
-			duration,
-			created_at,
-			updated_at
-		FROM
-			film
-	`
+	where, args := filmFilter(req)
+
+	query := `SELECT COUNT(*) OVER(),` + filmColumns + filmFrom + where
 
 	query += offset + limit
 
-	rows, err := f.db.Query(ctx, query)
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 
-		var (
-			id          sql.NullString
-			title       sql.NullString
-			description sql.NullString
-			releaseYear sql.NullString
-			duration    sql.NullInt32
-			createdAt   sql.NullString
-			updatedAt   sql.NullString
-		)
-
-		err := rows.Scan(
-			&resp.Count,
-			&id,
-			&title,
-			&description,
-			&releaseYear,
-			&duration,
-			&createdAt,
-			&updatedAt,
-		)
-
+		film, err := scanFilm(rows, &resp.Count)
 		if err != nil {
 			return nil, err
 		}
 
-		resp.Films = append(resp.Films, &models.Film{
-			Id:          id.String,
-			Title:       title.String,
-			Description: description.String,
-			ReleaseYear: releaseYear.String,
-			Duration:    duration.Int32,
-			CreatedAt:   createdAt.String,
-			UpdatedAt:   updatedAt.String,
-		})
+		resp.Films = append(resp.Films, film)
+	}
+
+	return &resp, rows.Err()
+}
+
+// Export streams every film matching the list request to fn row by row
+// instead of collecting them like GetList. Limit and offset are optional.
+func (f *filmRepo) Export(ctx context.Context, req *models.GetListFilmRequest, fn func(*models.Film) error) error {
+
+	where, args := filmFilter(req)
+
+	query := `SELECT` + filmColumns + filmFrom + where + `
+		ORDER BY f.created_at
+	`
+
+	if req.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	if req.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		film, err := scanFilm(rows)
+		if err != nil {
+			return err
+		}
+
+		err = fn(film)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// filmFilter turns the optional list filters into a WHERE clause and its args.
+func filmFilter(req *models.GetListFilmRequest) (string, []interface{}) {
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if len(req.Ratings) > 0 {
+		add("f.rating::text = ANY($%d)", req.Ratings)
+	}
+
+	if req.MinRentalRate != nil {
+		add("f.rental_rate >= $%d", req.MinRentalRate)
+	}
+
+	if req.MaxRentalRate != nil {
+		add("f.rental_rate <= $%d", req.MaxRentalRate)
+	}
+
+	if req.MinReplacementCost != nil {
+		add("f.replacement_cost >= $%d", req.MinReplacementCost)
+	}
+
+	if req.MaxReplacementCost != nil {
+		add("f.replacement_cost <= $%d", req.MaxReplacementCost)
+	}
+
+	if req.CategoryId != "" && req.IncludeDescendants {
+		add(`EXISTS (
+			SELECT 1 FROM film_category fc
+			WHERE fc.film_id = f.film_id AND fc.category_id IN (
+				WITH RECURSIVE tree AS (
+					SELECT $%d::UUID AS category_id
+					UNION
+					SELECT c.category_id FROM category c JOIN tree t ON c.parent_id = t.category_id
+				)
+				SELECT category_id FROM tree
+			)
+		)`, req.CategoryId)
+	} else if req.CategoryId != "" {
+		add("EXISTS (SELECT 1 FROM film_category fc WHERE fc.film_id = f.film_id AND fc.category_id = $%d)", req.CategoryId)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanFilm(row rowScanner, prefix ...interface{}) (*models.Film, error) {
+
+	var (
+		id                 sql.NullString
+		title              sql.NullString
+		description        sql.NullString
+		releaseYear        sql.NullString
+		duration           sql.NullInt32
+		language           nullLanguage
+		originalLanguage   nullLanguage
+		rating             sql.NullString
+		rentalDuration     sql.NullInt32
+		rentalRate         decimal.Decimal
+		replacementCost    decimal.Decimal
+		specialFeatures    []string
+		averageRating      decimal.NullDecimal
+		ratingCount        sql.NullInt32
+		posterUrl          sql.NullString
+		posterThumbnailUrl sql.NullString
+		trailerUrl         sql.NullString
+		createdAt          sql.NullString
+		updatedAt          sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&title,
+		&description,
+		&releaseYear,
+		&duration,
+		&language.id,
+		&language.name,
+		&language.createdAt,
+		&language.updatedAt,
+		&originalLanguage.id,
+		&originalLanguage.name,
+		&originalLanguage.createdAt,
+		&originalLanguage.updatedAt,
+		&rating,
+		&rentalDuration,
+		&rentalRate,
+		&replacementCost,
+		&specialFeatures,
+		&averageRating,
+		&ratingCount,
+		&posterUrl,
+		&posterThumbnailUrl,
+		&trailerUrl,
+		&createdAt,
+		&updatedAt,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	film := &models.Film{
+		Id:                 id.String,
+		Title:              title.String,
+		Description:        description.String,
+		ReleaseYear:        releaseYear.String,
+		Duration:           duration.Int32,
+		Language:           language.model(),
+		OriginalLanguage:   originalLanguage.model(),
+		Rating:             rating.String,
+		RentalDuration:     rentalDuration.Int32,
+		RentalRate:         rentalRate,
+		ReplacementCost:    replacementCost,
+		SpecialFeatures:    specialFeatures,
+		RatingCount:        ratingCount.Int32,
+		PosterUrl:          posterUrl.String,
+		PosterThumbnailUrl: posterThumbnailUrl.String,
+		TrailerUrl:         trailerUrl.String,
+		CreatedAt:          createdAt.String,
+		UpdatedAt:          updatedAt.String,
+	}
+
+	if averageRating.Valid {
+		film.AverageRating = &averageRating.Decimal
+	}
+
+	return film, nil
+}
+
+// nullLanguage holds a language joined with LEFT JOIN, which is all NULL when
+// the film has none.
+type nullLanguage struct {
+	id        sql.NullString
+	name      sql.NullString
+	createdAt sql.NullString
+	updatedAt sql.NullString
+}
+
+func (l *nullLanguage) model() *models.Language {
 
+	if !l.id.Valid {
+		return nil
 	}
 
-	return &resp, err
+	return &models.Language{
+		Id:        l.id.String,
+		Name:      l.name.String,
+		CreatedAt: l.createdAt.String,
+		UpdatedAt: l.updatedAt.String,
+	}
 }
 
 func (f *filmRepo) Update(ctx context.Context, id string, req *models.UpdateFilm) (int64, error) {
 
+	req.ApplyDefaults()
+
 	var (
 		query  = ""
 		params map[string]interface{}
@@ -198,21 +393,43 @@ func (f *filmRepo) Update(ctx context.Context, id string, req *models.UpdateFilm
 			description = :description,
 			release_year = :release_year,
 			duration = :duration,
+			language_id = :language_id,
+			original_language_id = :original_language_id,
+			rating = :rating,
+			rental_duration = :rental_duration,
+			rental_rate = :rental_rate,
+			replacement_cost = :replacement_cost,
+			special_features = :special_features,
 			updated_at = now()
 		WHERE film_id = :film_id
 	`
 
 	params = map[string]interface{}{
-		"actor_id":     id,
-		"title":        req.Title,
-		"description":  req.Description,
-		"release_year": req.ReleaseYear,
-		"duration":     req.Duration,
+		"film_id":              id,
+		"title":                req.Title,
+		"description":          req.Description,
+		"release_year":         req.ReleaseYear,
+		"duration":             req.Duration,
+		"language_id":          nullIfEmpty(req.LanguageId),
+		"original_language_id": nullIfEmpty(req.OriginalLanguageId),
+		"rating":               req.Rating,
+		"rental_duration":      req.RentalDuration,
+		"rental_rate":          req.RentalRate,
+		"replacement_cost":     req.ReplacementCost,
+		"special_features":     req.SpecialFeatures,
 	}
 
 	query, args := helper.ReplaceQueryParams(query, params)
 
 	rowsAffected, err := f.db.Exec(ctx, query, args...)
+	if isUniqueViolation(err) {
+		return 0, storage.ErrAlreadyExists
+	}
+
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrReferenceNotFound
+	}
+
 	if err != nil {
 		return 0, err
 	}
@@ -220,12 +437,306 @@ func (f *filmRepo) Update(ctx context.Context, id string, req *models.UpdateFilm
 	return rowsAffected.RowsAffected(), nil
 }
 
-func (f *filmRepo) Delete(ctx context.Context, req *models.FilmPrimarKey) error {
+// Delete returns the poster and trailer the film had. It fails with
+// storage.ErrInUse once a copy of the film was rented, rentals keep their
+// inventory item.
+func (f *filmRepo) Delete(ctx context.Context, req *models.FilmPrimarKey) ([]*models.MediaAsset, error) {
+
+	assets, err := scanDeleted(f.db.QueryRow(ctx, deleteQuery("film", "film_id", filmMedia), req.Id), filmMedia)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if isForeignKeyViolation(err) {
+		return nil, storage.ErrInUse
+	}
+
+	return assets, err
+}
+
+// SetPoster points the film at a new poster and returns the key of the one it
+// replaced, empty when it had none. It fails with pgx.ErrNoRows when the film
+// does not exist.
+func (f *filmRepo) SetPoster(ctx context.Context, id string, poster *models.MediaAsset) (string, error) {
+
+	var previous sql.NullString
+
+	query := `
+		UPDATE
+			film f
+		SET
+			poster_key = $2,
+			poster_url = $3,
+			poster_thumbnail_url = $4,
+			updated_at = now()
+		FROM (
+			SELECT film_id, poster_key FROM film WHERE film_id = $1 FOR UPDATE
+		) old
+		WHERE f.film_id = old.film_id
+		RETURNING old.poster_key
+	`
+
+	err := f.db.QueryRow(ctx, query, id, poster.Key, poster.Url, poster.ThumbnailUrl).Scan(&previous)
+
+	return previous.String, err
+}
+
+// SetTrailer is SetPoster for the trailer.
+func (f *filmRepo) SetTrailer(ctx context.Context, id string, trailer *models.MediaAsset) (string, error) {
+
+	var previous sql.NullString
+
+	query := `
+		UPDATE
+			film f
+		SET
+			trailer_key = $2,
+			trailer_url = $3,
+			updated_at = now()
+		FROM (
+			SELECT film_id, trailer_key FROM film WHERE film_id = $1 FOR UPDATE
+		) old
+		WHERE f.film_id = old.film_id
+		RETURNING old.trailer_key
+	`
+
+	err := f.db.QueryRow(ctx, query, id, trailer.Key, trailer.Url).Scan(&previous)
+
+	return previous.String, err
+}
+
+// Upsert inserts the film or updates the existing row with the same natural
+// key. It reports whether a new row was created.
+func (f *filmRepo) Upsert(ctx context.Context, film *models.CreateFilm) (string, bool, error) {
+
+	film.ApplyDefaults()
+
+	var (
+		id      string
+		created bool
+	)
+
+	query := `
+		INSERT INTO film(
+			film_id,
+			title,
+			description,
+			release_year,
+			duration,
+			language_id,
+			original_language_id,
+			rating,
+			rental_duration,
+			rental_rate,
+			replacement_cost,
+			special_features,
+			updated_at
+		) VALUES ( $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, now() )
+		ON CONFLICT (title, release_year) DO UPDATE SET
+			description = EXCLUDED.description,
+			duration = EXCLUDED.duration,
+			language_id = EXCLUDED.language_id,
+			original_language_id = EXCLUDED.original_language_id,
+			rating = EXCLUDED.rating,
+			rental_duration = EXCLUDED.rental_duration,
+			rental_rate = EXCLUDED.rental_rate,
+			replacement_cost = EXCLUDED.replacement_cost,
+			special_features = EXCLUDED.special_features,
+			updated_at = now()
+		RETURNING film_id, xmax = 0
+	`
+
+	err := f.db.QueryRow(ctx, query,
+		uuid.New().String(),
+		film.Title,
+		film.Description,
+		film.ReleaseYear,
+		film.Duration,
+		nullIfEmpty(film.LanguageId),
+		nullIfEmpty(film.OriginalLanguageId),
+		film.Rating,
+		film.RentalDuration,
+		film.RentalRate,
+		film.ReplacementCost,
+		film.SpecialFeatures,
+	).Scan(&id, &created)
+
+	if isForeignKeyViolation(err) {
+		return "", false, storage.ErrReferenceNotFound
+	}
 
-	_, err := f.db.Exec(ctx, "DELETE FROM film WHERE film_id = $1", req.Id)
 	if err != nil {
-		return err
+		return "", false, err
+	}
+
+	return id, created, nil
+}
+
+func (f *filmRepo) AddActor(ctx context.Context, req *models.FilmActorPrimarKey) error {
+
+	query := `
+		INSERT INTO film_actor(
+			film_id,
+			actor_id
+		) VALUES ( $1, $2 )
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err := f.db.Exec(ctx, query, req.FilmId, req.ActorId)
+	if isForeignKeyViolation(err) {
+		return storage.ErrReferenceNotFound
 	}
 
 	return err
 }
+
+func (f *filmRepo) RemoveActor(ctx context.Context, req *models.FilmActorPrimarKey) (int64, error) {
+
+	rowsAffected, err := f.db.Exec(ctx, "DELETE FROM film_actor WHERE film_id = $1 AND actor_id = $2", req.FilmId, req.ActorId)
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func (f *filmRepo) GetActors(ctx context.Context, req *models.FilmPrimarKey) (*models.GetFilmActorsResponse, error) {
+
+	var resp = models.GetFilmActorsResponse{Actors: []*models.Actor{}}
+
+	query := `SELECT` + actorColumns + `
+		FROM
+			film_actor fa
+		JOIN actor a ON a.actor_id = fa.actor_id
+		WHERE fa.film_id = $1
+		ORDER BY a.last_name, a.first_name
+	`
+
+	rows, err := f.db.Query(ctx, query, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		actor, err := scanActor(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Actors = append(resp.Actors, actor)
+	}
+
+	resp.Count = int32(len(resp.Actors))
+
+	return &resp, rows.Err()
+}
+
+func (f *filmRepo) AddCategory(ctx context.Context, req *models.FilmCategoryPrimarKey) error {
+
+	query := `
+		INSERT INTO film_category(
+			film_id,
+			category_id
+		) VALUES ( $1, $2 )
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err := f.db.Exec(ctx, query, req.FilmId, req.CategoryId)
+	if isForeignKeyViolation(err) {
+		return storage.ErrReferenceNotFound
+	}
+
+	return err
+}
+
+func (f *filmRepo) RemoveCategory(ctx context.Context, req *models.FilmCategoryPrimarKey) (int64, error) {
+
+	rowsAffected, err := f.db.Exec(ctx, "DELETE FROM film_category WHERE film_id = $1 AND category_id = $2", req.FilmId, req.CategoryId)
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func (f *filmRepo) GetCategories(ctx context.Context, req *models.FilmPrimarKey) (*models.GetFilmCategoriesResponse, error) {
+
+	var resp = models.GetFilmCategoriesResponse{Categories: []*models.Category{}}
+
+	query := `SELECT` + categoryColumns + `
+		FROM
+			film_category fc
+		JOIN category c ON c.category_id = fc.category_id
+		WHERE fc.film_id = $1
+		ORDER BY c.name
+	`
+
+	rows, err := f.db.Query(ctx, query, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Categories = append(resp.Categories, category)
+	}
+
+	resp.Count = int32(len(resp.Categories))
+
+	return &resp, rows.Err()
+}
+
+func (f *filmRepo) CreateMany(ctx context.Context, req []*models.CreateFilm, mode string) ([]*models.BatchResult, error) {
+
+	var rows = make([][]interface{}, 0, len(req))
+
+	for _, item := range req {
+		item.ApplyDefaults()
+
+		rows = append(rows, []interface{}{
+			uuid.New().String(),
+			item.Title,
+			item.Description,
+			item.ReleaseYear,
+			item.Duration,
+			nullIfEmpty(item.LanguageId),
+			nullIfEmpty(item.OriginalLanguageId),
+			item.Rating,
+			item.RentalDuration,
+			item.RentalRate,
+			item.ReplacementCost,
+			item.SpecialFeatures,
+		})
+	}
+
+	return insertMany(ctx, f.db, "film",
+		[]string{
+			"film_id",
+			"title",
+			"description",
+			"release_year",
+			"duration",
+			"language_id",
+			"original_language_id",
+			"rating",
+			"rental_duration",
+			"rental_rate",
+			"replacement_cost",
+			"special_features",
+		},
+		rows,
+		mode,
+	)
+}
+
+func (f *filmRepo) DeleteMany(ctx context.Context, ids []string, mode string) ([]*models.BatchResult, []*models.MediaAsset, error) {
+	return deleteMany(ctx, f.db, "film", "film_id", ids, mode, filmMedia)
+}
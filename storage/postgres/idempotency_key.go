@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+)
+
+type idempotencyKeyRepo struct {
+	db DB
+}
+
+func NewIdempotencyKeyRepo(db DB) *idempotencyKeyRepo {
+	return &idempotencyKeyRepo{
+		db: db,
+	}
+}
+
+// Reserve claims the key for a new request. When the key is already taken
+// it returns the stored record and false instead.
+func (f *idempotencyKeyRepo) Reserve(ctx context.Context, pkey *models.IdempotencyKeyPrimarKey, requestHash string, ttl time.Duration) (*models.IdempotencyKey, bool, error) {
+
+	_, err := f.db.Exec(ctx,
+		"DELETE FROM idempotency_key WHERE scope = $1 AND idempotency_key = $2 AND expires_at <= now()",
+		pkey.Scope,
+		pkey.Key,
+	)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	query := `
+		INSERT INTO idempotency_key(
+			scope,
+			idempotency_key,
+			request_hash,
+			expires_at
+		) VALUES ( $1, $2, $3, now() + make_interval(secs => $4) )
+		ON CONFLICT (scope, idempotency_key) DO NOTHING
+	`
+
+	result, err := f.db.Exec(ctx, query,
+		pkey.Scope,
+		pkey.Key,
+		requestHash,
+		ttl.Seconds(),
+	)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if result.RowsAffected() == 1 {
+		return nil, true, nil
+	}
+
+	record, err := f.GetByPKey(ctx, pkey)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The conflicting key expired or was released in between.
+		return f.Reserve(ctx, pkey, requestHash, ttl)
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return record, false, nil
+}
+
+func (f *idempotencyKeyRepo) GetByPKey(ctx context.Context, pkey *models.IdempotencyKeyPrimarKey) (*models.IdempotencyKey, error) {
+
+	var (
+		scope        sql.NullString
+		key          sql.NullString
+		requestHash  sql.NullString
+		statusCode   sql.NullInt32
+		responseBody []byte
+		completed    bool
+		createdAt    sql.NullString
+		expiresAt    sql.NullString
+	)
+
+	query := `
+		SELECT
+			scope,
+			idempotency_key,
+			request_hash,
+			status_code,
+			response_body,
+			completed_at IS NOT NULL,
+			created_at,
+			expires_at
+		FROM
+			idempotency_key
+		WHERE scope = $1 AND idempotency_key = $2
+	`
+
+	err := f.db.QueryRow(ctx, query, pkey.Scope, pkey.Key).
+		Scan(
+			&scope,
+			&key,
+			&requestHash,
+			&statusCode,
+			&responseBody,
+			&completed,
+			&createdAt,
+			&expiresAt,
+		)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.IdempotencyKey{
+		Scope:        scope.String,
+		Key:          key.String,
+		RequestHash:  requestHash.String,
+		StatusCode:   statusCode.Int32,
+		ResponseBody: responseBody,
+		Completed:    completed,
+		CreatedAt:    createdAt.String,
+		ExpiresAt:    expiresAt.String,
+	}, nil
+}
+
+func (f *idempotencyKeyRepo) Complete(ctx context.Context, pkey *models.IdempotencyKeyPrimarKey, statusCode int, responseBody []byte) error {
+
+	query := `
+		UPDATE
+			idempotency_key
+		SET
+			status_code = $3,
+			response_body = $4,
+			completed_at = now()
+		WHERE scope = $1 AND idempotency_key = $2
+	`
+
+	_, err := f.db.Exec(ctx, query, pkey.Scope, pkey.Key, statusCode, responseBody)
+
+	return err
+}
+
+func (f *idempotencyKeyRepo) Release(ctx context.Context, pkey *models.IdempotencyKeyPrimarKey) error {
+
+	_, err := f.db.Exec(ctx, "DELETE FROM idempotency_key WHERE scope = $1 AND idempotency_key = $2", pkey.Scope, pkey.Key)
+
+	return err
+}
+
+func (f *idempotencyKeyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+
+	result, err := f.db.Exec(ctx, "DELETE FROM idempotency_key WHERE expires_at <= now()")
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,340 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"crud/models"
+	"crud/storage"
+)
+
+type inventoryRepo struct {
+	db DB
+}
+
+func NewInventoryRepo(db DB) *inventoryRepo {
+	return &inventoryRepo{
+		db: db,
+	}
+}
+
+func (f *inventoryRepo) Create(ctx context.Context, inventory *models.CreateInventory) (string, error) {
+
+	var (
+		id    = uuid.New().String()
+		query string
+	)
+
+	query = `
+		INSERT INTO inventory(
+			inventory_id,
+			film_id,
+			store_id,
+			updated_at
+		) VALUES ( $1, $2, $3, now() )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		id,
+		inventory.FilmId,
+		inventory.StoreId,
+	)
+
+	if isForeignKeyViolation(err) {
+		return "", storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (f *inventoryRepo) GetByPKey(ctx context.Context, pkey *models.InventoryPrimarKey) (*models.Inventory, error) {
+
+	query := `
+		SELECT
+			inventory_id,
+			film_id,
+			store_id,
+			status,
+			created_at,
+			updated_at
+		FROM
+			inventory
+		WHERE inventory_id = $1
+	`
+
+	return scanInventory(f.db.QueryRow(ctx, query, pkey.Id))
+}
+
+func (f *inventoryRepo) GetList(ctx context.Context, req *models.GetListInventoryRequest) (*models.GetListInventoryResponse, error) {
+
+	var (
+		resp       = models.GetListInventoryResponse{}
+		offset     = " OFFSET 0"
+		limit      = " LIMIT 5"
+		conditions []string
+		args       []interface{}
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	for column, value := range map[string]string{
+		"film_id":  req.FilmId,
+		"store_id": req.StoreId,
+		"status":   req.Status,
+	} {
+		if value != "" {
+			args = append(args, value)
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+		}
+	}
+
+	query := `
+		SELECT
+			COUNT(*) OVER(),
+			inventory_id,
+			film_id,
+			store_id,
+			status,
+			created_at,
+			updated_at
+		FROM
+			inventory
+	`
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at" + offset + limit
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		inventory, err := scanInventory(rows, &resp.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Inventories = append(resp.Inventories, inventory)
+	}
+
+	return &resp, rows.Err()
+}
+
+func (f *inventoryRepo) Update(ctx context.Context, id string, req *models.UpdateInventory) (int64, error) {
+
+	query := `
+		UPDATE
+			inventory
+		SET
+			store_id = $2,
+			updated_at = now()
+		WHERE inventory_id = $1
+	`
+
+	rowsAffected, err := f.db.Exec(ctx, query, id, req.StoreId)
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func (f *inventoryRepo) Delete(ctx context.Context, req *models.InventoryPrimarKey) (int64, error) {
+
+	rowsAffected, err := f.db.Exec(ctx, "DELETE FROM inventory WHERE inventory_id = $1", req.Id)
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrInUse
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+// CountByFilm counts the copies of a film in every store that holds at least one.
+func (f *inventoryRepo) CountByFilm(ctx context.Context, req *models.FilmPrimarKey) (*models.GetFilmInventoryResponse, error) {
+
+	var resp = models.GetFilmInventoryResponse{
+		FilmId: req.Id,
+		Stores: []*models.FilmStoreInventory{},
+	}
+
+	query := `
+		SELECT
+			s.store_id,
+			s.name,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE i.status = 'available'),
+			COUNT(*) FILTER (WHERE i.status = 'rented')
+		FROM
+			inventory i
+		JOIN store s ON s.store_id = i.store_id
+		WHERE i.film_id = $1
+		GROUP BY s.store_id, s.name
+		ORDER BY s.name
+	`
+
+	rows, err := f.db.Query(ctx, query, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var store models.FilmStoreInventory
+
+		err := rows.Scan(
+			&store.StoreId,
+			&store.StoreName,
+			&store.Total,
+			&store.Available,
+			&store.Rented,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Total += store.Total
+		resp.Available += store.Available
+		resp.Rented += store.Rented
+		resp.Stores = append(resp.Stores, &store)
+	}
+
+	return &resp, rows.Err()
+}
+
+// CountByStore counts the copies of every film a store holds.
+func (f *inventoryRepo) CountByStore(ctx context.Context, req *models.GetStoreInventoryRequest) (*models.GetStoreInventoryResponse, error) {
+
+	var (
+		resp = models.GetStoreInventoryResponse{
+			StoreId: req.StoreId,
+			Films:   []*models.StoreFilmInventory{},
+		}
+		offset = " OFFSET 0"
+		limit  = " LIMIT 5"
+		having string
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	if req.AvailableOnly {
+		having = " HAVING COUNT(*) FILTER (WHERE i.status = 'available') > 0"
+	}
+
+	query := `
+		SELECT
+			COUNT(*) OVER(),
+			f.film_id,
+			f.title,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE i.status = 'available'),
+			COUNT(*) FILTER (WHERE i.status = 'rented')
+		FROM
+			inventory i
+		JOIN film f ON f.film_id = i.film_id
+		WHERE i.store_id = $1
+		GROUP BY f.film_id, f.title
+	` + having + `
+		ORDER BY f.title
+	`
+
+	query += offset + limit
+
+	rows, err := f.db.Query(ctx, query, req.StoreId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var film models.StoreFilmInventory
+
+		err := rows.Scan(
+			&resp.Count,
+			&film.FilmId,
+			&film.Title,
+			&film.Total,
+			&film.Available,
+			&film.Rented,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Films = append(resp.Films, &film)
+	}
+
+	return &resp, rows.Err()
+}
+
+func scanInventory(row rowScanner, prefix ...interface{}) (*models.Inventory, error) {
+
+	var (
+		id        sql.NullString
+		filmId    sql.NullString
+		storeId   sql.NullString
+		status    sql.NullString
+		createdAt sql.NullString
+		updatedAt sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&filmId,
+		&storeId,
+		&status,
+		&createdAt,
+		&updatedAt,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Inventory{
+		Id:        id.String,
+		FilmId:    filmId.String,
+		StoreId:   storeId.String,
+		Status:    status.String,
+		CreatedAt: createdAt.String,
+		UpdatedAt: updatedAt.String,
+	}, nil
+}
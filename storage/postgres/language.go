@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"crud/models"
+	"crud/pkg/helper"
+	"crud/storage"
+)
+
+type languageRepo struct {
+	db DB
+}
+
+func NewLanguageRepo(db DB) *languageRepo {
+	return &languageRepo{
+		db: db,
+	}
+}
+
+func (f *languageRepo) Create(ctx context.Context, language *models.CreateLanguage) (string, error) {
+
+	var (
+		id    = uuid.New().String()
+		query string
+	)
+
+	query = `
+		INSERT INTO language(
+			language_id,
+			name,
+			updated_at
+		) VALUES ( $1, $2, now() )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		id,
+		language.Name,
+	)
+
+	if isUniqueViolation(err) {
+		return "", storage.ErrAlreadyExists
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (f *languageRepo) GetByPKey(ctx context.Context, pkey *models.LanguagePrimarKey) (*models.Language, error) {
+
+	var (
+		id        sql.NullString
+		name      sql.NullString
+		createdAt sql.NullString
+		updatedAt sql.NullString
+	)
+
+	query := `
+		SELECT
+			language_id,
+			name,
+			created_at,
+			updated_at
+		FROM
+			language
+		WHERE language_id = $1
+	`
+
+	err := f.db.QueryRow(ctx, query, pkey.Id).
+		Scan(
+			&id,
+			&name,
+			&createdAt,
+			&updatedAt,
+		)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Language{
+		Id:        id.String,
+		Name:      name.String,
+		CreatedAt: createdAt.String,
+		UpdatedAt: updatedAt.String,
+	}, nil
+}
+
+func (f *languageRepo) GetList(ctx context.Context, req *models.GetListLanguageRequest) (*models.GetListLanguageResponse, error) {
+
+	var (
+		resp   = models.GetListLanguageResponse{}
+		offset = " OFFSET 0"
+		limit  = " LIMIT 5"
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	query := `
+		SELECT
+			COUNT(*) OVER(),
+			language_id,
+			name,
+			created_at,
+			updated_at
+		FROM
+			language
+		ORDER BY name
+	`
+
+	query += offset + limit
+
+	rows, err := f.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var (
+			id        sql.NullString
+			name      sql.NullString
+			createdAt sql.NullString
+			updatedAt sql.NullString
+		)
+
+		err := rows.Scan(
+			&resp.Count,
+			&id,
+			&name,
+			&createdAt,
+			&updatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Languages = append(resp.Languages, &models.Language{
+			Id:        id.String,
+			Name:      name.String,
+			CreatedAt: createdAt.String,
+			UpdatedAt: updatedAt.String,
+		})
+
+	}
+
+	return &resp, rows.Err()
+}
+
+func (f *languageRepo) Update(ctx context.Context, id string, req *models.UpdateLanguage) (int64, error) {
+
+	var (
+		query  = ""
+		params map[string]interface{}
+	)
+
+	query = `
+		UPDATE
+			language
+		SET
+			name = :name,
+			updated_at = now()
+		WHERE language_id = :language_id
+	`
+
+	params = map[string]interface{}{
+		"language_id": id,
+		"name":        req.Name,
+	}
+
+	query, args := helper.ReplaceQueryParams(query, params)
+
+	rowsAffected, err := f.db.Exec(ctx, query, args...)
+	if isUniqueViolation(err) {
+		return 0, storage.ErrAlreadyExists
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func (f *languageRepo) Delete(ctx context.Context, req *models.LanguagePrimarKey) (int64, error) {
+
+	rowsAffected, err := f.db.Exec(ctx, "DELETE FROM language WHERE language_id = $1", req.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"crud/models"
+)
+
+// mediaColumns names the columns an upload kind of an entity is stored in,
+// thumbnail is empty for kinds without one.
+type mediaColumns struct {
+	key       string
+	thumbnail string
+}
+
+var filmMedia = []mediaColumns{
+	{key: "poster_key", thumbnail: "poster_thumbnail_url"},
+	{key: "trailer_key"},
+}
+
+var actorMedia = []mediaColumns{
+	{key: "photo_key", thumbnail: "photo_thumbnail_url"},
+}
+
+// deleteQuery deletes one row by primary key and returns its id followed by
+// the key and thumbnail url of every upload kind, for scanDeleted.
+func deleteQuery(table string, idColumn string, media []mediaColumns) string {
+
+	returning := []string{idColumn + "::VARCHAR"}
+
+	for _, m := range media {
+
+		thumbnail := "''"
+		if m.thumbnail != "" {
+			thumbnail = fmt.Sprintf("COALESCE(%s, '')", m.thumbnail)
+		}
+
+		returning = append(returning, fmt.Sprintf("COALESCE(%s, '')", m.key), thumbnail)
+	}
+
+	return fmt.Sprintf("DELETE FROM %s WHERE %s = $1 RETURNING %s", table, idColumn, strings.Join(returning, ", "))
+}
+
+// scanDeleted reads the row returned by a deleteQuery and returns the uploads
+// the deleted row had, so that they can be removed from the blob store once
+// the delete is committed.
+func scanDeleted(row rowScanner, media []mediaColumns) ([]*models.MediaAsset, error) {
+
+	var (
+		id     string
+		values = make([]string, 2*len(media))
+		dest   = []interface{}{&id}
+		assets []*models.MediaAsset
+	)
+
+	for i := range values {
+		dest = append(dest, &values[i])
+	}
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(values); i += 2 {
+		if values[i] != "" {
+			assets = append(assets, &models.MediaAsset{Key: values[i], ThumbnailUrl: values[i+1]})
+		}
+	}
+
+	return assets, nil
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type passwordResetRepo struct {
+	db DB
+}
+
+func NewPasswordResetRepo(db DB) *passwordResetRepo {
+	return &passwordResetRepo{
+		db: db,
+	}
+}
+
+func (f *passwordResetRepo) Create(ctx context.Context, userId string, tokenHash string, ttl time.Duration) error {
+
+	query := `
+		INSERT INTO password_reset_token(
+			password_reset_token_id,
+			user_id,
+			token_hash,
+			expires_at
+		) VALUES ( $1, $2, $3, now() + make_interval(secs => $4) )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		uuid.New().String(),
+		userId,
+		tokenHash,
+		ttl.Seconds(),
+	)
+
+	return err
+}
+
+// Consume marks an unused, unexpired reset token as used and returns the
+// user it belongs to.
+func (f *passwordResetRepo) Consume(ctx context.Context, tokenHash string) (string, error) {
+
+	var userId string
+
+	query := `
+		UPDATE
+			password_reset_token
+		SET
+			used_at = now()
+		WHERE token_hash = $1
+			AND used_at IS NULL
+			AND expires_at > now()
+		RETURNING user_id
+	`
+
+	err := f.db.QueryRow(ctx, query, tokenHash).Scan(&userId)
+	if err != nil {
+		return "", err
+	}
+
+	return userId, nil
+}
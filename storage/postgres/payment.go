@@ -0,0 +1,354 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+
+	"crud/models"
+	"crud/storage"
+)
+
+type paymentRepo struct {
+	db DB
+}
+
+func NewPaymentRepo(db DB) *paymentRepo {
+	return &paymentRepo{
+		db: db,
+	}
+}
+
+// Create records a payment against a rental. The rental row is locked so
+// that concurrent payments can not together pay more than is due.
+func (f *paymentRepo) Create(ctx context.Context, req *models.CreatePayment) (string, error) {
+
+	var (
+		id          = uuid.New().String()
+		customerId  string
+		outstanding decimal.Decimal
+	)
+
+	tx, err := f.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		SELECT
+			customer_id
+		FROM
+			rental
+		WHERE rental_id = $1
+		FOR UPDATE
+	`, req.RentalId).Scan(&customerId)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	err = tx.QueryRow(ctx, `
+		SELECT
+			r.rental_fee + r.late_fee - COALESCE(SUM(p.amount), 0)
+		FROM
+			rental r
+		LEFT JOIN payment p ON p.rental_id = r.rental_id
+		WHERE r.rental_id = $1
+		GROUP BY r.rental_id
+	`, req.RentalId).Scan(&outstanding)
+
+	if err != nil {
+		return "", err
+	}
+
+	amount := outstanding
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+
+	if !amount.IsPositive() || amount.GreaterThan(outstanding) {
+		return "", storage.ErrAmountExceedsDue
+	}
+
+	query := `
+		INSERT INTO payment(
+			payment_id,
+			customer_id,
+			rental_id,
+			staff_id,
+			amount
+		) VALUES ( $1, $2, $3, $4, $5 )
+	`
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		customerId,
+		req.RentalId,
+		req.StaffId,
+		amount,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+// Refund records a refund of a payment as a negative payment. The payment
+// row is locked so that concurrent refunds can not exceed it.
+func (f *paymentRepo) Refund(ctx context.Context, pkey *models.PaymentPrimarKey, req *models.RefundPayment) (string, error) {
+
+	var (
+		id         = uuid.New().String()
+		customerId string
+		rentalId   string
+		isRefund   bool
+		refundable decimal.Decimal
+	)
+
+	tx, err := f.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		SELECT
+			customer_id,
+			rental_id,
+			refund_of IS NOT NULL
+		FROM
+			payment
+		WHERE payment_id = $1
+		FOR UPDATE
+	`, pkey.Id).Scan(&customerId, &rentalId, &isRefund)
+
+	if err != nil {
+		return "", err
+	}
+
+	if isRefund {
+		return "", storage.ErrNotRefundable
+	}
+
+	err = tx.QueryRow(ctx, `
+		SELECT
+			p.amount + COALESCE(SUM(r.amount), 0)
+		FROM
+			payment p
+		LEFT JOIN payment r ON r.refund_of = p.payment_id
+		WHERE p.payment_id = $1
+		GROUP BY p.payment_id
+	`, pkey.Id).Scan(&refundable)
+
+	if err != nil {
+		return "", err
+	}
+
+	amount := refundable
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+
+	if !amount.IsPositive() || amount.GreaterThan(refundable) {
+		return "", storage.ErrAmountExceedsRefundable
+	}
+
+	query := `
+		INSERT INTO payment(
+			payment_id,
+			customer_id,
+			rental_id,
+			staff_id,
+			amount,
+			refund_of
+		) VALUES ( $1, $2, $3, $4, $5, $6 )
+	`
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		customerId,
+		rentalId,
+		req.StaffId,
+		amount.Neg(),
+		pkey.Id,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+const paymentColumns = `
+			payment_id,
+			customer_id,
+			rental_id,
+			amount,
+			refund_of,
+			staff_id,
+			payment_date,
+			created_at
+		FROM
+			payment
+`
+
+func (f *paymentRepo) GetByPKey(ctx context.Context, pkey *models.PaymentPrimarKey) (*models.Payment, error) {
+
+	query := `SELECT` + paymentColumns + `
+		WHERE payment_id = $1
+	`
+
+	return scanPayment(f.db.QueryRow(ctx, query, pkey.Id))
+}
+
+func (f *paymentRepo) GetList(ctx context.Context, req *models.GetListPaymentRequest) (*models.GetListPaymentResponse, error) {
+
+	var (
+		resp       = models.GetListPaymentResponse{}
+		offset     = " OFFSET 0"
+		limit      = " LIMIT 5"
+		conditions []string
+		args       []interface{}
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	if req.CustomerId != "" {
+		args = append(args, req.CustomerId)
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+
+	if req.RentalId != "" {
+		args = append(args, req.RentalId)
+		conditions = append(conditions, fmt.Sprintf("rental_id = $%d", len(args)))
+	}
+
+	if req.StaffId != "" {
+		args = append(args, req.StaffId)
+		conditions = append(conditions, fmt.Sprintf("staff_id = $%d", len(args)))
+	}
+
+	query := `SELECT COUNT(*) OVER(),` + paymentColumns
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY payment_date DESC" + offset + limit
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		payment, err := scanPayment(rows, &resp.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Payments = append(resp.Payments, payment)
+	}
+
+	return &resp, rows.Err()
+}
+
+// GetBalance sums the fees charged on the rentals of a customer against the
+// payments and refunds recorded for them.
+func (f *paymentRepo) GetBalance(ctx context.Context, req *models.CustomerPrimarKey) (*models.CustomerBalance, error) {
+
+	var resp = models.CustomerBalance{CustomerId: req.Id}
+
+	query := `
+		SELECT
+			(SELECT COALESCE(SUM(rental_fee + late_fee), 0) FROM rental WHERE customer_id = $1),
+			COALESCE(SUM(amount) FILTER (WHERE amount > 0), 0),
+			COALESCE(-SUM(amount) FILTER (WHERE amount < 0), 0)
+		FROM
+			payment
+		WHERE customer_id = $1
+	`
+
+	err := f.db.QueryRow(ctx, query, req.Id).Scan(
+		&resp.Charged,
+		&resp.Paid,
+		&resp.Refunded,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Balance = resp.Charged.Sub(resp.Paid).Add(resp.Refunded)
+
+	return &resp, nil
+}
+
+func scanPayment(row rowScanner, prefix ...interface{}) (*models.Payment, error) {
+
+	var (
+		id          sql.NullString
+		customerId  sql.NullString
+		rentalId    sql.NullString
+		amount      decimal.Decimal
+		refundOf    sql.NullString
+		staffId     sql.NullString
+		paymentDate sql.NullString
+		createdAt   sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&customerId,
+		&rentalId,
+		&amount,
+		&refundOf,
+		&staffId,
+		&paymentDate,
+		&createdAt,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := models.PaymentKindPayment
+	if refundOf.Valid {
+		kind = models.PaymentKindRefund
+	}
+
+	return &models.Payment{
+		Id:          id.String,
+		CustomerId:  customerId.String,
+		RentalId:    rentalId.String,
+		Kind:        kind,
+		Amount:      amount,
+		RefundOf:    refundOf.String,
+		StaffId:     staffId.String,
+		PaymentDate: paymentDate.String,
+		CreatedAt:   createdAt.String,
+	}, nil
+}
@@ -4,17 +4,48 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 
 	"crud/config"
 	"crud/storage"
 )
 
+// DB is implemented by both *pgxpool.Pool and pgx.Tx so that every repo can
+// run inside a transaction started by Store.WithTx.
+type DB interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
 type Store struct {
-	db       *pgxpool.Pool
-	film     *filmRepo
-	actor    *actorRepo
-	category *categoryRepo
+	pool      *pgxpool.Pool
+	db        DB
+	film      *filmRepo
+	actor     *actorRepo
+	category  *categoryRepo
+	language  *languageRepo
+	store     *storeRepo
+	inventory *inventoryRepo
+	country   *countryRepo
+	city      *cityRepo
+	address   *addressRepo
+	customer  *customerRepo
+	staff     *staffRepo
+	rental    *rentalRepo
+	payment   *paymentRepo
+	apiKey    *apiKeyRepo
+	user      *userRepo
+	refresh   *refreshTokenRepo
+	reset     *passwordResetRepo
+	idemKey   *idempotencyKeyRepo
+	search    *searchRepo
+	report    *reportRepo
+	review    *reviewRepo
+	recommend *recommendationRepo
 }
 
 func NewPostgres(ctx context.Context, cfg config.Config) (storage.StorageI, error) {
@@ -38,15 +69,55 @@ func NewPostgres(ctx context.Context, cfg config.Config) (storage.StorageI, erro
 	}
 
 	return &Store{
-		db:       pool,
-		film:     NewFilmRepo(pool),
-		actor:    NewActorRepo(pool),
-		category: NewCategoryRepo(pool),
+		pool:      pool,
+		db:        pool,
+		film:      NewFilmRepo(pool),
+		actor:     NewActorRepo(pool),
+		category:  NewCategoryRepo(pool),
+		language:  NewLanguageRepo(pool),
+		store:     NewStoreRepo(pool),
+		inventory: NewInventoryRepo(pool),
+		country:   NewCountryRepo(pool),
+		city:      NewCityRepo(pool),
+		address:   NewAddressRepo(pool),
+		customer:  NewCustomerRepo(pool),
+		staff:     NewStaffRepo(pool),
+		rental:    NewRentalRepo(pool),
+		payment:   NewPaymentRepo(pool),
+		apiKey:    NewApiKeyRepo(pool),
+		user:      NewUserRepo(pool),
+		refresh:   NewRefreshTokenRepo(pool),
+		reset:     NewPasswordResetRepo(pool),
+		idemKey:   NewIdempotencyKeyRepo(pool),
+		search:    NewSearchRepo(pool),
+		report:    NewReportRepo(pool),
+		review:    NewReviewRepo(pool),
+		recommend: NewRecommendationRepo(pool),
 	}, err
 }
 
 func (s *Store) CloseDB() {
-	s.db.Close()
+	if s.pool != nil {
+		s.pool.Close()
+	}
+}
+
+// WithTx runs fn with a store whose repos share one transaction. It is
+// committed when fn returns nil and rolled back otherwise.
+func (s *Store) WithTx(ctx context.Context, fn func(tx storage.StorageI) error) error {
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = fn(&Store{db: tx})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (s *Store) Film() storage.FilmRepoI {
@@ -75,3 +146,185 @@ func (s *Store) Category() storage.CategoryRepoI {
 
 	return s.category
 }
+
+func (s *Store) Language() storage.LanguageRepoI {
+
+	if s.language == nil {
+		s.language = NewLanguageRepo(s.db)
+	}
+
+	return s.language
+}
+
+func (s *Store) Store() storage.StoreRepoI {
+
+	if s.store == nil {
+		s.store = NewStoreRepo(s.db)
+	}
+
+	return s.store
+}
+
+func (s *Store) Inventory() storage.InventoryRepoI {
+
+	if s.inventory == nil {
+		s.inventory = NewInventoryRepo(s.db)
+	}
+
+	return s.inventory
+}
+
+func (s *Store) Country() storage.CountryRepoI {
+
+	if s.country == nil {
+		s.country = NewCountryRepo(s.db)
+	}
+
+	return s.country
+}
+
+func (s *Store) City() storage.CityRepoI {
+
+	if s.city == nil {
+		s.city = NewCityRepo(s.db)
+	}
+
+	return s.city
+}
+
+func (s *Store) Address() storage.AddressRepoI {
+
+	if s.address == nil {
+		s.address = NewAddressRepo(s.db)
+	}
+
+	return s.address
+}
+
+func (s *Store) Customer() storage.CustomerRepoI {
+
+	if s.customer == nil {
+		s.customer = NewCustomerRepo(s.db)
+	}
+
+	return s.customer
+}
+
+func (s *Store) Staff() storage.StaffRepoI {
+
+	if s.staff == nil {
+		s.staff = NewStaffRepo(s.db)
+	}
+
+	return s.staff
+}
+
+func (s *Store) Rental() storage.RentalRepoI {
+
+	if s.rental == nil {
+		s.rental = NewRentalRepo(s.db)
+	}
+
+	return s.rental
+}
+
+func (s *Store) Payment() storage.PaymentRepoI {
+
+	if s.payment == nil {
+		s.payment = NewPaymentRepo(s.db)
+	}
+
+	return s.payment
+}
+
+func (s *Store) ApiKey() storage.ApiKeyRepoI {
+
+	if s.apiKey == nil {
+		s.apiKey = NewApiKeyRepo(s.db)
+	}
+
+	return s.apiKey
+}
+
+func (s *Store) User() storage.UserRepoI {
+
+	if s.user == nil {
+		s.user = NewUserRepo(s.db)
+	}
+
+	return s.user
+}
+
+func (s *Store) RefreshToken() storage.RefreshTokenRepoI {
+
+	if s.refresh == nil {
+		s.refresh = NewRefreshTokenRepo(s.db)
+	}
+
+	return s.refresh
+}
+
+func (s *Store) PasswordReset() storage.PasswordResetRepoI {
+
+	if s.reset == nil {
+		s.reset = NewPasswordResetRepo(s.db)
+	}
+
+	return s.reset
+}
+
+func (s *Store) IdempotencyKey() storage.IdempotencyKeyRepoI {
+
+	if s.idemKey == nil {
+		s.idemKey = NewIdempotencyKeyRepo(s.db)
+	}
+
+	return s.idemKey
+}
+
+func (s *Store) Search() storage.SearchRepoI {
+
+	if s.search == nil {
+		s.search = NewSearchRepo(s.db)
+	}
+
+	return s.search
+}
+
+func (s *Store) Report() storage.ReportRepoI {
+
+	if s.report == nil {
+		s.report = NewReportRepo(s.db)
+	}
+
+	return s.report
+}
+
+func (s *Store) Review() storage.ReviewRepoI {
+
+	if s.review == nil {
+		s.review = NewReviewRepo(s.db)
+	}
+
+	return s.review
+}
+
+func (s *Store) Recommendation() storage.RecommendationRepoI {
+
+	if s.recommend == nil {
+		s.recommend = NewRecommendationRepo(s.db)
+	}
+
+	return s.recommend
+}
+
+// nullIfEmpty maps an empty optional id to NULL so that it does not trip the
+// foreign key or the uuid cast.
+func nullIfEmpty(s string) interface{} {
+
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
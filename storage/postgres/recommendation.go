@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"crud/models"
+)
+
+// similarityScore weighs the columns of film_similarity with the weights
+// passed as $2, $3 and $4.
+const similarityScore = `s.shared_actors * $2::FLOAT8 + s.shared_categories * $3::FLOAT8 + s.co_renters * $4::FLOAT8`
+
+type recommendationRepo struct {
+	db DB
+}
+
+func NewRecommendationRepo(db DB) *recommendationRepo {
+	return &recommendationRepo{
+		db: db,
+	}
+}
+
+// Similar returns the films that have the most in common with the film,
+// read from the film_similarity snapshot.
+func (f *recommendationRepo) Similar(ctx context.Context, req *models.GetSimilarFilmsRequest) (*models.GetSimilarFilmsResponse, error) {
+
+	var (
+		resp   = models.GetSimilarFilmsResponse{Films: []*models.SimilarFilm{}}
+		offset = " OFFSET 0"
+		limit  = " LIMIT 5"
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	query := `
+		WITH scored AS (
+			SELECT
+				s.similar_film_id,
+				` + similarityScore + ` AS score,
+				s.shared_actors,
+				s.shared_categories,
+				s.co_renters,
+				s.refreshed_at
+			FROM
+				film_similarity s
+			WHERE s.film_id = $1
+		)
+		SELECT
+			COUNT(*) OVER(),
+			sc.score,
+			sc.shared_actors,
+			sc.shared_categories,
+			sc.co_renters,
+			sc.refreshed_at,` + filmColumns + filmFrom + `
+		JOIN scored sc ON sc.similar_film_id = f.film_id
+		WHERE sc.score > 0
+		ORDER BY sc.score DESC, f.title
+	` + offset + limit
+
+	rows, err := f.db.Query(ctx, query,
+		req.FilmId,
+		req.Weights.Actor,
+		req.Weights.Category,
+		req.Weights.CoRenter,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var (
+			similar     models.SimilarFilm
+			refreshedAt sql.NullString
+		)
+
+		film, err := scanFilm(rows,
+			&resp.Count,
+			&similar.Score,
+			&similar.SharedActors,
+			&similar.SharedCategories,
+			&similar.CoRenters,
+			&refreshedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		similar.Film = *film
+		resp.RefreshedAt = refreshedAt.String
+		resp.Films = append(resp.Films, &similar)
+	}
+
+	return &resp, rows.Err()
+}
+
+// ForCustomer sums the similarity of every film to the films the customer
+// rented and returns the best scoring ones they have not rented yet.
+func (f *recommendationRepo) ForCustomer(ctx context.Context, req *models.GetRecommendationsRequest) (*models.GetRecommendationsResponse, error) {
+
+	var (
+		resp   = models.GetRecommendationsResponse{Films: []*models.RecommendedFilm{}}
+		offset = " OFFSET 0"
+		limit  = " LIMIT 5"
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	query := `
+		WITH rented AS (
+			SELECT DISTINCT
+				i.film_id
+			FROM
+				rental r
+			JOIN inventory i ON i.inventory_id = r.inventory_id
+			WHERE r.customer_id = $1
+		), scored AS (
+			SELECT
+				s.similar_film_id,
+				SUM(` + similarityScore + `) AS score,
+				MAX(s.refreshed_at) AS refreshed_at
+			FROM
+				film_similarity s
+			WHERE s.film_id IN (SELECT film_id FROM rented)
+				AND s.similar_film_id NOT IN (SELECT film_id FROM rented)
+			GROUP BY s.similar_film_id
+		)
+		SELECT
+			COUNT(*) OVER(),
+			sc.score,
+			sc.refreshed_at,` + filmColumns + filmFrom + `
+		JOIN scored sc ON sc.similar_film_id = f.film_id
+		WHERE sc.score > 0
+		ORDER BY sc.score DESC, f.title
+	` + offset + limit
+
+	rows, err := f.db.Query(ctx, query,
+		req.CustomerId,
+		req.Weights.Actor,
+		req.Weights.Category,
+		req.Weights.CoRenter,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var (
+			recommended models.RecommendedFilm
+			refreshedAt sql.NullString
+		)
+
+		film, err := scanFilm(rows,
+			&resp.Count,
+			&recommended.Score,
+			&refreshedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		recommended.Film = *film
+		resp.RefreshedAt = refreshedAt.String
+		resp.Films = append(resp.Films, &recommended)
+	}
+
+	return &resp, rows.Err()
+}
+
+// Refresh recomputes the film_similarity snapshot without blocking readers.
+func (f *recommendationRepo) Refresh(ctx context.Context) error {
+
+	_, err := f.db.Exec(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY film_similarity")
+
+	return err
+}
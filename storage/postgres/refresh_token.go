@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"crud/storage"
+)
+
+type refreshTokenRepo struct {
+	db DB
+}
+
+func NewRefreshTokenRepo(db DB) *refreshTokenRepo {
+	return &refreshTokenRepo{
+		db: db,
+	}
+}
+
+func (f *refreshTokenRepo) Create(ctx context.Context, userId string, tokenHash string, ttl time.Duration) error {
+
+	query := `
+		INSERT INTO refresh_token(
+			refresh_token_id,
+			user_id,
+			token_hash,
+			expires_at
+		) VALUES ( $1, $2, $3, now() + make_interval(secs => $4) )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		uuid.New().String(),
+		userId,
+		tokenHash,
+		ttl.Seconds(),
+	)
+
+	return err
+}
+
+// Rotate revokes the presented refresh token and issues its replacement in
+// one transaction. Presenting an already revoked token is treated as theft
+// and revokes every token of the user.
+func (f *refreshTokenRepo) Rotate(ctx context.Context, tokenHash string, newTokenHash string, ttl time.Duration) (string, error) {
+
+	var (
+		id      string
+		userId  string
+		revoked bool
+		expired bool
+		newId   = uuid.New().String()
+	)
+
+	tx, err := f.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT
+			refresh_token_id,
+			user_id,
+			revoked_at IS NOT NULL,
+			expires_at <= now()
+		FROM
+			refresh_token
+		WHERE token_hash = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(ctx, query, tokenHash).Scan(&id, &userId, &revoked, &expired)
+	if err != nil {
+		return "", err
+	}
+
+	if revoked {
+		_, err = tx.Exec(ctx, "UPDATE refresh_token SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL", userId)
+		if err != nil {
+			return "", err
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		return "", storage.ErrTokenReused
+	}
+
+	if expired {
+		return "", storage.ErrTokenExpired
+	}
+
+	query = `
+		INSERT INTO refresh_token(
+			refresh_token_id,
+			user_id,
+			token_hash,
+			expires_at
+		) VALUES ( $1, $2, $3, now() + make_interval(secs => $4) )
+	`
+
+	_, err = tx.Exec(ctx, query, newId, userId, newTokenHash, ttl.Seconds())
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE refresh_token SET revoked_at = now(), replaced_by = $2 WHERE refresh_token_id = $1", id, newId)
+	if err != nil {
+		return "", err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return userId, nil
+}
+
+func (f *refreshTokenRepo) Revoke(ctx context.Context, tokenHash string) (int64, error) {
+
+	rowsAffected, err := f.db.Exec(ctx, "UPDATE refresh_token SET revoked_at = now() WHERE token_hash = $1 AND revoked_at IS NULL", tokenHash)
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func (f *refreshTokenRepo) RevokeAllByUser(ctx context.Context, userId string) error {
+
+	_, err := f.db.Exec(ctx, "UPDATE refresh_token SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL", userId)
+
+	return err
+}
@@ -0,0 +1,377 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+
+	"crud/models"
+	"crud/pkg/fee"
+	"crud/storage"
+)
+
+type rentalRepo struct {
+	db DB
+}
+
+func NewRentalRepo(db DB) *rentalRepo {
+	return &rentalRepo{
+		db: db,
+	}
+}
+
+// Checkout rents an available inventory item to an active customer. The item
+// row is locked for the rest of the transaction so that two concurrent
+// checkouts of the same copy can not both succeed; the loser sees the copy as
+// rented and gets storage.ErrInventoryUnavailable.
+func (f *rentalRepo) Checkout(ctx context.Context, req *models.CreateRental) (string, error) {
+
+	var (
+		id             = uuid.New().String()
+		status         string
+		rentalDuration int32
+		rentalRate     decimal.Decimal
+		active         bool
+	)
+
+	tx, err := f.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		SELECT
+			i.status,
+			f.rental_duration,
+			f.rental_rate
+		FROM
+			inventory i
+		JOIN film f ON f.film_id = i.film_id
+		WHERE i.inventory_id = $1
+		FOR UPDATE OF i
+	`, req.InventoryId).Scan(&status, &rentalDuration, &rentalRate)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if status != models.InventoryStatusAvailable {
+		return "", storage.ErrInventoryUnavailable
+	}
+
+	err = tx.QueryRow(ctx, "SELECT active FROM customer WHERE customer_id = $1", req.CustomerId).Scan(&active)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if !active {
+		return "", storage.ErrCustomerInactive
+	}
+
+	query := `
+		INSERT INTO rental(
+			rental_id,
+			inventory_id,
+			customer_id,
+			staff_id,
+			rental_date,
+			due_date,
+			rental_fee,
+			updated_at
+		) VALUES ( $1, $2, $3, $4, now(), now() + make_interval(days => $5), $6, now() )
+	`
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		req.InventoryId,
+		req.CustomerId,
+		req.StaffId,
+		rentalDuration,
+		rentalRate,
+	)
+
+	if isUniqueViolation(err) {
+		return "", storage.ErrInventoryUnavailable
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE inventory SET status = $2, updated_at = now() WHERE inventory_id = $1",
+		req.InventoryId,
+		models.InventoryStatusRented,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+// Return closes an open rental on behalf of the staff member, charges the
+// late fee computed by policy and puts the copy back on the shelf.
+func (f *rentalRepo) Return(ctx context.Context, req *models.RentalPrimarKey, staffId string, policy fee.Policy) error {
+
+	var (
+		inventoryId string
+		returned    bool
+		rental      fee.Rental
+	)
+
+	tx, err := f.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// The rental period is taken from the rental itself so that later
+	// changes to the film do not change what the customer agreed to.
+	err = tx.QueryRow(ctx, `
+		SELECT
+			r.inventory_id,
+			r.return_date IS NOT NULL,
+			r.rental_fee,
+			f.replacement_cost,
+			EXTRACT(DAY FROM r.due_date - r.rental_date)::INTEGER,
+			r.rental_date,
+			now()::TIMESTAMP
+		FROM
+			rental r
+		JOIN inventory i ON i.inventory_id = r.inventory_id
+		JOIN film f ON f.film_id = i.film_id
+		WHERE r.rental_id = $1
+		FOR UPDATE OF r
+	`, req.Id).Scan(
+		&inventoryId,
+		&returned,
+		&rental.RentalRate,
+		&rental.ReplacementCost,
+		&rental.RentalDuration,
+		&rental.RentalDate,
+		&rental.ReturnDate,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if returned {
+		return storage.ErrAlreadyReturned
+	}
+
+	charge := policy.Calculate(rental)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE
+			rental
+		SET
+			return_date = $2,
+			rental_fee = $3,
+			late_fee = $4,
+			return_staff_id = $5,
+			updated_at = now()
+		WHERE rental_id = $1
+	`, req.Id, rental.ReturnDate, charge.RentalFee, charge.LateFee, staffId)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE inventory SET status = $2, updated_at = now() WHERE inventory_id = $1",
+		inventoryId,
+		models.InventoryStatusAvailable,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+const (
+	rentalColumns = `
+			r.rental_id,
+			r.inventory_id,
+			i.film_id,
+			f.title,
+			i.store_id,
+			r.customer_id,
+			r.staff_id,
+			r.return_staff_id,
+			CASE
+				WHEN r.return_date IS NOT NULL THEN 'returned'
+				WHEN r.due_date < now() THEN 'overdue'
+				ELSE 'open'
+			END,
+			r.rental_date,
+			r.due_date,
+			r.return_date,
+			r.rental_fee,
+			r.late_fee,
+			r.created_at,
+			r.updated_at
+	`
+
+	rentalFrom = `
+		FROM
+			rental r
+		JOIN inventory i ON i.inventory_id = r.inventory_id
+		JOIN film f ON f.film_id = i.film_id
+	`
+)
+
+func (f *rentalRepo) GetByPKey(ctx context.Context, pkey *models.RentalPrimarKey) (*models.Rental, error) {
+
+	query := `SELECT` + rentalColumns + rentalFrom + `
+		WHERE r.rental_id = $1
+	`
+
+	return scanRental(f.db.QueryRow(ctx, query, pkey.Id))
+}
+
+func (f *rentalRepo) GetList(ctx context.Context, req *models.GetListRentalRequest) (*models.GetListRentalResponse, error) {
+
+	var (
+		resp       = models.GetListRentalResponse{}
+		offset     = " OFFSET 0"
+		limit      = " LIMIT 5"
+		conditions []string
+		args       []interface{}
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	if req.CustomerId != "" {
+		args = append(args, req.CustomerId)
+		conditions = append(conditions, fmt.Sprintf("r.customer_id = $%d", len(args)))
+	}
+
+	if req.StaffId != "" {
+		args = append(args, req.StaffId)
+		conditions = append(conditions, fmt.Sprintf("r.staff_id = $%d", len(args)))
+	}
+
+	switch req.Status {
+	case models.RentalStatusReturned:
+		conditions = append(conditions, "r.return_date IS NOT NULL")
+	case models.RentalStatusOpen:
+		conditions = append(conditions, "r.return_date IS NULL")
+	case models.RentalStatusOverdue:
+		conditions = append(conditions, "r.return_date IS NULL AND r.due_date < now()")
+	}
+
+	query := `SELECT COUNT(*) OVER(),` + rentalColumns + rentalFrom
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY r.rental_date DESC" + offset + limit
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		rental, err := scanRental(rows, &resp.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Rentals = append(resp.Rentals, rental)
+	}
+
+	return &resp, rows.Err()
+}
+
+func scanRental(row rowScanner, prefix ...interface{}) (*models.Rental, error) {
+
+	var (
+		id            sql.NullString
+		inventoryId   sql.NullString
+		filmId        sql.NullString
+		title         sql.NullString
+		storeId       sql.NullString
+		customerId    sql.NullString
+		staffId       sql.NullString
+		returnStaffId sql.NullString
+		status        sql.NullString
+		rentalDate    sql.NullString
+		dueDate       sql.NullString
+		returnDate    sql.NullString
+		rentalFee     decimal.Decimal
+		lateFee       decimal.Decimal
+		createdAt     sql.NullString
+		updatedAt     sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&inventoryId,
+		&filmId,
+		&title,
+		&storeId,
+		&customerId,
+		&staffId,
+		&returnStaffId,
+		&status,
+		&rentalDate,
+		&dueDate,
+		&returnDate,
+		&rentalFee,
+		&lateFee,
+		&createdAt,
+		&updatedAt,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Rental{
+		Id:            id.String,
+		InventoryId:   inventoryId.String,
+		FilmId:        filmId.String,
+		Title:         title.String,
+		StoreId:       storeId.String,
+		CustomerId:    customerId.String,
+		StaffId:       staffId.String,
+		ReturnStaffId: returnStaffId.String,
+		Status:        status.String,
+		RentalDate:    rentalDate.String,
+		DueDate:       dueDate.String,
+		ReturnDate:    returnDate.String,
+		RentalFee:     rentalFee,
+		LateFee:       lateFee,
+		CreatedAt:     createdAt.String,
+		UpdatedAt:     updatedAt.String,
+	}, nil
+}
@@ -0,0 +1,344 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"crud/models"
+)
+
+// reportViews are the materialized views refreshed by Refresh.
+var reportViews = []string{
+	"report_category_revenue",
+	"report_actor_popularity",
+}
+
+type reportRepo struct {
+	db DB
+}
+
+func NewReportRepo(db DB) *reportRepo {
+	return &reportRepo{
+		db: db,
+	}
+}
+
+// TopFilms ranks films by the rentals made in the period. Revenue is what
+// was paid for those rentals, refunds deducted.
+func (f *reportRepo) TopFilms(ctx context.Context, req *models.GetTopFilmsRequest) (*models.GetTopFilmsResponse, error) {
+
+	var (
+		resp   = models.GetTopFilmsResponse{Films: []*models.TopFilm{}}
+		offset = " OFFSET 0"
+		limit  = " LIMIT 10"
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	conditions, args := periodConditions("r.rental_date", req.ReportPeriod)
+
+	if req.StoreId != "" {
+		args = append(args, req.StoreId)
+		conditions = append(conditions, fmt.Sprintf("i.store_id = $%d", len(args)))
+	}
+
+	query := `
+		SELECT
+			f.film_id,
+			f.title,
+			COUNT(*),
+			COALESCE(SUM(p.amount), 0)
+		FROM
+			rental r
+		JOIN inventory i ON i.inventory_id = r.inventory_id
+		JOIN film f ON f.film_id = i.film_id
+		LEFT JOIN (
+			SELECT rental_id, SUM(amount) AS amount FROM payment GROUP BY rental_id
+		) p ON p.rental_id = r.rental_id
+	`
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " GROUP BY f.film_id, f.title ORDER BY 3 DESC, 4 DESC, f.title" + offset + limit
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var film models.TopFilm
+
+		err := rows.Scan(
+			&film.FilmId,
+			&film.Title,
+			&film.Rentals,
+			&film.Revenue,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Films = append(resp.Films, &film)
+	}
+
+	return &resp, rows.Err()
+}
+
+// CategoryRevenue reads the revenue per category from its materialized
+// view. Share is the part of the total over all categories.
+func (f *reportRepo) CategoryRevenue(ctx context.Context) (*models.GetCategoryRevenueResponse, error) {
+
+	var resp = models.GetCategoryRevenueResponse{Categories: []*models.CategoryRevenue{}}
+
+	query := `
+		SELECT
+			category_id,
+			name,
+			films,
+			rentals,
+			revenue,
+			COALESCE(ROUND(revenue / NULLIF(SUM(revenue) OVER (), 0), 4), 0),
+			refreshed_at
+		FROM
+			report_category_revenue
+		ORDER BY revenue DESC, name
+	`
+
+	rows, err := f.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var (
+			category    models.CategoryRevenue
+			refreshedAt sql.NullString
+		)
+
+		err := rows.Scan(
+			&category.CategoryId,
+			&category.Name,
+			&category.Films,
+			&category.Rentals,
+			&category.Revenue,
+			&category.Share,
+			&refreshedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp.RefreshedAt = refreshedAt.String
+		resp.Categories = append(resp.Categories, &category)
+	}
+
+	return &resp, rows.Err()
+}
+
+// ActorPopularity reads the rentals of the films of each actor from its
+// materialized view, most rented first.
+func (f *reportRepo) ActorPopularity(ctx context.Context, req *models.GetActorPopularityRequest) (*models.GetActorPopularityResponse, error) {
+
+	var (
+		resp   = models.GetActorPopularityResponse{Actors: []*models.ActorPopularity{}}
+		offset = " OFFSET 0"
+		limit  = " LIMIT 10"
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	query := `
+		SELECT
+			COUNT(*) OVER(),
+			actor_id,
+			first_name,
+			last_name,
+			films,
+			rentals,
+			revenue,
+			refreshed_at
+		FROM
+			report_actor_popularity
+		ORDER BY rentals DESC, revenue DESC, last_name, first_name
+	` + offset + limit
+
+	rows, err := f.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var (
+			actor       models.ActorPopularity
+			refreshedAt sql.NullString
+		)
+
+		err := rows.Scan(
+			&resp.Count,
+			&actor.ActorId,
+			&actor.FirstName,
+			&actor.LastName,
+			&actor.Films,
+			&actor.Rentals,
+			&actor.Revenue,
+			&refreshedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp.RefreshedAt = refreshedAt.String
+		resp.Actors = append(resp.Actors, &actor)
+	}
+
+	return &resp, rows.Err()
+}
+
+// StoreRevenue sums the payments taken in the period. Grouped by store a
+// payment counts for the store the copy was rented from, grouped by staff
+// for the store of the staff member that took it. Stores and staff without
+// payments are listed with zeros.
+func (f *reportRepo) StoreRevenue(ctx context.Context, req *models.GetStoreRevenueRequest) (*models.GetStoreRevenueResponse, error) {
+
+	var resp = models.GetStoreRevenueResponse{
+		GroupBy: req.GroupBy,
+		Stores:  []*models.StoreRevenue{},
+	}
+
+	conditions, args := periodConditions("p.payment_date", req.ReportPeriod)
+
+	period := ""
+	if len(conditions) > 0 {
+		period = " AND " + strings.Join(conditions, " AND ")
+	}
+
+	const sums = `
+			COUNT(p.payment_id) FILTER (WHERE p.amount > 0),
+			COALESCE(SUM(p.amount) FILTER (WHERE p.amount > 0), 0),
+			COALESCE(-SUM(p.amount) FILTER (WHERE p.amount < 0), 0),
+			COALESCE(SUM(p.amount), 0)
+	`
+
+	query := `
+		SELECT
+			s.store_id,
+			s.name,
+			'',
+			'',` + sums + `
+		FROM
+			store s
+		LEFT JOIN inventory i ON i.store_id = s.store_id
+		LEFT JOIN rental r ON r.inventory_id = i.inventory_id
+		LEFT JOIN payment p ON p.rental_id = r.rental_id` + period + `
+		GROUP BY s.store_id, s.name
+		ORDER BY 8 DESC, s.name
+	`
+
+	if req.GroupBy == models.ReportGroupByStaff {
+		query = `
+			SELECT
+				s.store_id,
+				s.name,
+				st.staff_id::VARCHAR,
+				st.first_name || ' ' || st.last_name,` + sums + `
+			FROM
+				staff st
+			JOIN store s ON s.store_id = st.store_id
+			LEFT JOIN payment p ON p.staff_id = st.staff_id` + period + `
+			GROUP BY s.store_id, s.name, st.staff_id, st.first_name, st.last_name
+			ORDER BY s.name, 8 DESC, st.last_name, st.first_name
+		`
+	}
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var store models.StoreRevenue
+
+		err := rows.Scan(
+			&store.StoreId,
+			&store.StoreName,
+			&store.StaffId,
+			&store.StaffName,
+			&store.Payments,
+			&store.Revenue,
+			&store.Refunded,
+			&store.Net,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Stores = append(resp.Stores, &store)
+	}
+
+	return &resp, rows.Err()
+}
+
+// Refresh recomputes the materialized views behind the heavy reports. They
+// are refreshed concurrently so that readers are not blocked meanwhile.
+func (f *reportRepo) Refresh(ctx context.Context) error {
+
+	for _, view := range reportViews {
+		_, err := f.db.Exec(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY "+view)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// periodConditions turns the bounds of the period that are set into
+// conditions on column, numbering the parameters from $1.
+func periodConditions(column string, period models.ReportPeriod) ([]string, []interface{}) {
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if !period.From.IsZero() {
+		args = append(args, period.From)
+		conditions = append(conditions, fmt.Sprintf("%s >= $%d", column, len(args)))
+	}
+
+	if !period.To.IsZero() {
+		args = append(args, period.To)
+		conditions = append(conditions, fmt.Sprintf("%s < $%d", column, len(args)))
+	}
+
+	return conditions, args
+}
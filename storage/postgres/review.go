@@ -0,0 +1,296 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	"crud/models"
+	"crud/storage"
+)
+
+type reviewRepo struct {
+	db DB
+}
+
+func NewReviewRepo(db DB) *reviewRepo {
+	return &reviewRepo{
+		db: db,
+	}
+}
+
+// Create stores the review as pending, it counts towards the rating of the
+// film once it is approved. A user can review a film only once.
+func (f *reviewRepo) Create(ctx context.Context, review *models.CreateReview) (string, error) {
+
+	var (
+		id    = uuid.New().String()
+		query string
+	)
+
+	query = `
+		INSERT INTO review(
+			review_id,
+			film_id,
+			user_id,
+			rating,
+			body,
+			updated_at
+		) VALUES ( $1, $2, $3, $4, $5, now() )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		id,
+		review.FilmId,
+		review.UserId,
+		review.Rating,
+		review.Body,
+	)
+
+	if isUniqueViolation(err) {
+		return "", storage.ErrAlreadyExists
+	}
+
+	if isForeignKeyViolation(err) {
+		return "", storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+const reviewColumns = `
+			review_id,
+			film_id,
+			user_id,
+			rating,
+			body,
+			status,
+			helpful_count,
+			created_at,
+			updated_at
+		FROM
+			review
+`
+
+func (f *reviewRepo) GetByPKey(ctx context.Context, pkey *models.ReviewPrimarKey) (*models.Review, error) {
+
+	query := `SELECT` + reviewColumns + `
+		WHERE review_id = $1
+	`
+
+	return scanReview(f.db.QueryRow(ctx, query, pkey.Id))
+}
+
+func (f *reviewRepo) GetList(ctx context.Context, req *models.GetListReviewRequest) (*models.GetListReviewResponse, error) {
+
+	var (
+		resp       = models.GetListReviewResponse{}
+		offset     = " OFFSET 0"
+		limit      = " LIMIT 5"
+		order      = " ORDER BY created_at DESC"
+		conditions []string
+		args       []interface{}
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	if req.Sort == models.ReviewSortHelpful {
+		order = " ORDER BY helpful_count DESC, created_at DESC"
+	}
+
+	if req.FilmId != "" {
+		args = append(args, req.FilmId)
+		conditions = append(conditions, fmt.Sprintf("film_id = $%d", len(args)))
+	}
+
+	if req.Status != "" {
+		args = append(args, req.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `SELECT COUNT(*) OVER(),` + reviewColumns
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += order + offset + limit
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		review, err := scanReview(rows, &resp.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Reviews = append(resp.Reviews, review)
+	}
+
+	return &resp, rows.Err()
+}
+
+// SetStatus moderates the review and recomputes the rating of its film in
+// the same transaction.
+func (f *reviewRepo) SetStatus(ctx context.Context, id string, req *models.UpdateReviewStatus) (int64, error) {
+
+	var filmId string
+
+	tx, err := f.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Moderations of reviews of the same film queue up on the film row so
+	// that each recount sees the ones committed before it.
+	err = tx.QueryRow(ctx, `
+		SELECT
+			f.film_id
+		FROM
+			film f
+		JOIN review r ON r.film_id = f.film_id
+		WHERE r.review_id = $1
+		FOR UPDATE OF f
+	`, id).Scan(&filmId)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE review SET status = $2, updated_at = now() WHERE review_id = $1", id, req.Status)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE
+			film
+		SET
+			average_rating = r.average,
+			rating_count = r.count
+		FROM (
+			SELECT
+				ROUND(AVG(rating), 2) AS average,
+				COUNT(*) AS count
+			FROM
+				review
+			WHERE film_id = $1 AND status = $2
+		) r
+		WHERE film_id = $1
+	`, filmId, models.ReviewStatusApproved)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return 1, tx.Commit(ctx)
+}
+
+// Vote marks the review as helpful for the user or takes that back. Voting
+// twice is a no-op, helpful_count only moves when a vote is added or
+// removed.
+func (f *reviewRepo) Vote(ctx context.Context, req *models.ReviewVote) error {
+
+	var (
+		query = `INSERT INTO review_vote (review_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+		delta = 1
+	)
+
+	if !req.Helpful {
+		query = `DELETE FROM review_vote WHERE review_id = $1 AND user_id = $2`
+		delta = -1
+	}
+
+	tx, err := f.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, query, req.ReviewId, req.UserId)
+	if isForeignKeyViolation(err) {
+		return storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() > 0 {
+		_, err = tx.Exec(ctx, "UPDATE review SET helpful_count = helpful_count + $2 WHERE review_id = $1", req.ReviewId, delta)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func scanReview(row rowScanner, prefix ...interface{}) (*models.Review, error) {
+
+	var (
+		id           sql.NullString
+		filmId       sql.NullString
+		userId       sql.NullString
+		rating       sql.NullInt32
+		body         sql.NullString
+		status       sql.NullString
+		helpfulCount sql.NullInt32
+		createdAt    sql.NullString
+		updatedAt    sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&filmId,
+		&userId,
+		&rating,
+		&body,
+		&status,
+		&helpfulCount,
+		&createdAt,
+		&updatedAt,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Review{
+		Id:           id.String,
+		FilmId:       filmId.String,
+		UserId:       userId.String,
+		Rating:       rating.Int32,
+		Body:         body.String,
+		Status:       status.String,
+		HelpfulCount: helpfulCount.Int32,
+		CreatedAt:    createdAt.String,
+		UpdatedAt:    updatedAt.String,
+	}, nil
+}
@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+
+	"crud/models"
+)
+
+// ts_headline does not escape the text it highlights, so matches are
+// delimited with control characters that highlight turns into <mark> tags
+// after escaping.
+const (
+	highlightStart  = "\x01"
+	highlightStop   = "\x02"
+	headlineOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", MaxFragments=2, MinWords=5, MaxWords=20"
+)
+
+type searchRepo struct {
+	db DB
+}
+
+func NewSearchRepo(db DB) *searchRepo {
+	return &searchRepo{
+		db: db,
+	}
+}
+
+// Search runs one ranked query per requested type. Every word of the query
+// has to match, the last one also as a prefix so that results show up
+// while the user is still typing.
+func (f *searchRepo) Search(ctx context.Context, req *models.SearchRequest) (*models.SearchResponse, error) {
+
+	var (
+		resp   = models.SearchResponse{Query: req.Query}
+		offset = " OFFSET 0"
+		limit  = " LIMIT 5"
+		err    error
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	tsQuery := toTSQuery(req.Query)
+	if tsQuery == "" {
+		return &resp, nil
+	}
+
+	for _, typ := range req.Types {
+		switch typ {
+		case models.SearchTypeFilm:
+			resp.Films, err = f.search(ctx, typ, `
+				SELECT
+					COUNT(*) OVER(),
+					film_id,
+					title,
+					ts_headline('english', title, q, $2),
+					ts_headline('english', COALESCE(description, ''), q, $2),
+					ts_rank(search_vector, q) AS rank
+				FROM
+					film,
+					to_tsquery('english', $1) q
+				WHERE search_vector @@ q
+				ORDER BY rank DESC, title
+			`+offset+limit, tsQuery)
+		case models.SearchTypeActor:
+			resp.Actors, err = f.search(ctx, typ, `
+				SELECT
+					COUNT(*) OVER(),
+					actor_id,
+					first_name || ' ' || last_name,
+					ts_headline('simple', first_name || ' ' || last_name, q, $2),
+					'',
+					ts_rank(search_vector, q) AS rank
+				FROM
+					actor,
+					to_tsquery('simple', $1) q
+				WHERE search_vector @@ q
+				ORDER BY rank DESC, last_name, first_name
+			`+offset+limit, tsQuery)
+		case models.SearchTypeCategory:
+			resp.Categories, err = f.search(ctx, typ, `
+				SELECT
+					COUNT(*) OVER(),
+					category_id,
+					name,
+					ts_headline('simple', name, q, $2),
+					'',
+					ts_rank(search_vector, q) AS rank
+				FROM
+					category,
+					to_tsquery('simple', $1) q
+				WHERE search_vector @@ q
+				ORDER BY rank DESC, name
+			`+offset+limit, tsQuery)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &resp, nil
+}
+
+func (f *searchRepo) search(ctx context.Context, typ string, query string, tsQuery string) (*models.SearchGroup, error) {
+
+	var group = models.SearchGroup{Hits: []*models.SearchHit{}}
+
+	rows, err := f.db.Query(ctx, query, tsQuery, headlineOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var (
+			id       sql.NullString
+			title    sql.NullString
+			headline sql.NullString
+			snippet  sql.NullString
+			rank     float32
+		)
+
+		err := rows.Scan(
+			&group.Count,
+			&id,
+			&title,
+			&headline,
+			&snippet,
+			&rank,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		group.Hits = append(group.Hits, &models.SearchHit{
+			Type:     typ,
+			Id:       id.String,
+			Title:    title.String,
+			Headline: highlight(headline.String),
+			Snippet:  highlight(snippet.String),
+			Rank:     rank,
+		})
+	}
+
+	return &group, rows.Err()
+}
+
+// highlight escapes a ts_headline result for HTML and only then marks the
+// matches, so that stored text can never inject markup.
+func highlight(headline string) string {
+
+	replacer := strings.NewReplacer(
+		highlightStart, "<mark>",
+		highlightStop, "</mark>",
+	)
+
+	return replacer.Replace(html.EscapeString(headline))
+}
+
+// toTSQuery turns free text into a to_tsquery expression. Only letters and
+// digits are kept so user input can never break the tsquery syntax.
+func toTSQuery(text string) string {
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(words) == 0 {
+		return ""
+	}
+
+	for i := range words {
+		words[i] = strings.ToLower(words[i])
+	}
+
+	words[len(words)-1] += ":*"
+
+	return strings.Join(words, " & ")
+}
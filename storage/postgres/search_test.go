@@ -0,0 +1,45 @@
+package postgres
+
+import "testing"
+
+func TestHighlight(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		headline string
+		want     string
+	}{
+		{"plain", "Academy Dinosaur", "Academy Dinosaur"},
+		{"match", "Academy \x01Dinosaur\x02", "Academy <mark>Dinosaur</mark>"},
+		{"markup is escaped", "\x01<script>\x02alert(1)</script>", "<mark>&lt;script&gt;</mark>alert(1)&lt;/script&gt;"},
+		{"stored mark tags are escaped", "<mark>x</mark> & \x01y\x02", "&lt;mark&gt;x&lt;/mark&gt; &amp; <mark>y</mark>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlight(tt.headline); got != tt.want {
+				t.Errorf("highlight(%q) = %q, want %q", tt.headline, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToTSQuery(t *testing.T) {
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"", ""},
+		{"!!", ""},
+		{"dino", "dino:*"},
+		{"Academy Dino", "academy & dino:*"},
+		{"a|b & c:*", "a & b & c:*"},
+	}
+
+	for _, tt := range tests {
+		if got := toTSQuery(tt.text); got != tt.want {
+			t.Errorf("toTSQuery(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
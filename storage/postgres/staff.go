@@ -0,0 +1,305 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"crud/models"
+	"crud/storage"
+)
+
+type staffRepo struct {
+	db DB
+}
+
+func NewStaffRepo(db DB) *staffRepo {
+	return &staffRepo{
+		db: db,
+	}
+}
+
+func (f *staffRepo) Create(ctx context.Context, staff *models.CreateStaff) (string, error) {
+
+	var (
+		id     = uuid.New().String()
+		active = true
+		query  string
+	)
+
+	if staff.Active != nil {
+		active = *staff.Active
+	}
+
+	query = `
+		INSERT INTO staff(
+			staff_id,
+			store_id,
+			user_id,
+			manager_id,
+			first_name,
+			last_name,
+			email,
+			active,
+			updated_at
+		) VALUES ( $1, $2, $3, $4, $5, $6, LOWER($7), $8, now() )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		id,
+		staff.StoreId,
+		nullIfEmpty(staff.UserId),
+		nullIfEmpty(staff.ManagerId),
+		staff.FirstName,
+		staff.LastName,
+		staff.Email,
+		active,
+	)
+
+	if isUniqueViolation(err) {
+		return "", storage.ErrAlreadyExists
+	}
+
+	if isForeignKeyViolation(err) {
+		return "", storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+const staffColumns = `
+			staff_id,
+			store_id,
+			user_id,
+			manager_id,
+			first_name,
+			last_name,
+			email,
+			active,
+			created_at,
+			updated_at
+		FROM
+			staff
+`
+
+func (f *staffRepo) GetByPKey(ctx context.Context, pkey *models.StaffPrimarKey) (*models.Staff, error) {
+
+	query := `SELECT` + staffColumns + `
+		WHERE staff_id = $1
+	`
+
+	return scanStaff(f.db.QueryRow(ctx, query, pkey.Id))
+}
+
+// GetByUserId returns the staff member linked to the login account.
+func (f *staffRepo) GetByUserId(ctx context.Context, userId string) (*models.Staff, error) {
+
+	query := `SELECT` + staffColumns + `
+		WHERE user_id = $1
+	`
+
+	return scanStaff(f.db.QueryRow(ctx, query, userId))
+}
+
+func (f *staffRepo) GetList(ctx context.Context, req *models.GetListStaffRequest) (*models.GetListStaffResponse, error) {
+
+	var (
+		resp       = models.GetListStaffResponse{}
+		offset     = " OFFSET 0"
+		limit      = " LIMIT 5"
+		conditions []string
+		args       []interface{}
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if req.StoreId != "" {
+		add("store_id = $%d", req.StoreId)
+	}
+
+	if req.ManagerId != "" {
+		add("manager_id = $%d", req.ManagerId)
+	}
+
+	if req.Active != nil {
+		add("active = $%d", *req.Active)
+	}
+
+	query := `SELECT COUNT(*) OVER(),` + staffColumns
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY last_name, first_name" + offset + limit
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		staff, err := scanStaff(rows, &resp.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Staff = append(resp.Staff, staff)
+	}
+
+	return &resp, rows.Err()
+}
+
+// Update replaces the staff member. A manager that reports, directly or
+// through others, to the staff member is rejected with
+// storage.ErrManagerCycle.
+func (f *staffRepo) Update(ctx context.Context, id string, req *models.UpdateStaff) (int64, error) {
+
+	var cycle bool
+
+	tx, err := f.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if req.ManagerId != "" {
+		err = tx.QueryRow(ctx, `
+			WITH RECURSIVE chain AS (
+				SELECT staff_id, manager_id FROM staff WHERE staff_id = $2
+				UNION
+				SELECT s.staff_id, s.manager_id FROM staff s JOIN chain c ON s.staff_id = c.manager_id
+			)
+			SELECT EXISTS (SELECT 1 FROM chain WHERE staff_id = $1)
+		`, id, req.ManagerId).Scan(&cycle)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if cycle {
+			return 0, storage.ErrManagerCycle
+		}
+	}
+
+	query := `
+		UPDATE
+			staff
+		SET
+			store_id = $2,
+			user_id = $3,
+			manager_id = $4,
+			first_name = $5,
+			last_name = $6,
+			email = LOWER($7),
+			active = COALESCE($8, active),
+			updated_at = now()
+		WHERE staff_id = $1
+	`
+
+	rowsAffected, err := tx.Exec(ctx, query,
+		id,
+		req.StoreId,
+		nullIfEmpty(req.UserId),
+		nullIfEmpty(req.ManagerId),
+		req.FirstName,
+		req.LastName,
+		req.Email,
+		req.Active,
+	)
+
+	if isUniqueViolation(err) {
+		return 0, storage.ErrAlreadyExists
+	}
+
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrReferenceNotFound
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), tx.Commit(ctx)
+}
+
+func (f *staffRepo) Delete(ctx context.Context, req *models.StaffPrimarKey) (int64, error) {
+
+	rowsAffected, err := f.db.Exec(ctx, "DELETE FROM staff WHERE staff_id = $1", req.Id)
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrInUse
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func scanStaff(row rowScanner, prefix ...interface{}) (*models.Staff, error) {
+
+	var (
+		id        sql.NullString
+		storeId   sql.NullString
+		userId    sql.NullString
+		managerId sql.NullString
+		firstName sql.NullString
+		lastName  sql.NullString
+		email     sql.NullString
+		active    sql.NullBool
+		createdAt sql.NullString
+		updatedAt sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&storeId,
+		&userId,
+		&managerId,
+		&firstName,
+		&lastName,
+		&email,
+		&active,
+		&createdAt,
+		&updatedAt,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Staff{
+		Id:        id.String,
+		StoreId:   storeId.String,
+		UserId:    userId.String,
+		ManagerId: managerId.String,
+		FirstName: firstName.String,
+		LastName:  lastName.String,
+		Email:     email.String,
+		Active:    active.Bool,
+		CreatedAt: createdAt.String,
+		UpdatedAt: updatedAt.String,
+	}, nil
+}
@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"crud/models"
+	"crud/pkg/helper"
+	"crud/storage"
+)
+
+type storeRepo struct {
+	db DB
+}
+
+func NewStoreRepo(db DB) *storeRepo {
+	return &storeRepo{
+		db: db,
+	}
+}
+
+func (f *storeRepo) Create(ctx context.Context, store *models.CreateStore) (string, error) {
+
+	var (
+		id    = uuid.New().String()
+		query string
+	)
+
+	query = `
+		INSERT INTO store(
+			store_id,
+			name,
+			updated_at
+		) VALUES ( $1, $2, now() )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		id,
+		store.Name,
+	)
+
+	if isUniqueViolation(err) {
+		return "", storage.ErrAlreadyExists
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (f *storeRepo) GetByPKey(ctx context.Context, pkey *models.StorePrimarKey) (*models.Store, error) {
+
+	var (
+		id        sql.NullString
+		name      sql.NullString
+		createdAt sql.NullString
+		updatedAt sql.NullString
+	)
+
+	query := `
+		SELECT
+			store_id,
+			name,
+			created_at,
+			updated_at
+		FROM
+			store
+		WHERE store_id = $1
+	`
+
+	err := f.db.QueryRow(ctx, query, pkey.Id).
+		Scan(
+			&id,
+			&name,
+			&createdAt,
+			&updatedAt,
+		)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Store{
+		Id:        id.String,
+		Name:      name.String,
+		CreatedAt: createdAt.String,
+		UpdatedAt: updatedAt.String,
+	}, nil
+}
+
+func (f *storeRepo) GetList(ctx context.Context, req *models.GetListStoreRequest) (*models.GetListStoreResponse, error) {
+
+	var (
+		resp   = models.GetListStoreResponse{}
+		offset = " OFFSET 0"
+		limit  = " LIMIT 5"
+	)
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	query := `
+		SELECT
+			COUNT(*) OVER(),
+			store_id,
+			name,
+			created_at,
+			updated_at
+		FROM
+			store
+		ORDER BY name
+	`
+
+	query += offset + limit
+
+	rows, err := f.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var (
+			id        sql.NullString
+			name      sql.NullString
+			createdAt sql.NullString
+			updatedAt sql.NullString
+		)
+
+		err := rows.Scan(
+			&resp.Count,
+			&id,
+			&name,
+			&createdAt,
+			&updatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Stores = append(resp.Stores, &models.Store{
+			Id:        id.String,
+			Name:      name.String,
+			CreatedAt: createdAt.String,
+			UpdatedAt: updatedAt.String,
+		})
+
+	}
+
+	return &resp, rows.Err()
+}
+
+func (f *storeRepo) Update(ctx context.Context, id string, req *models.UpdateStore) (int64, error) {
+
+	var (
+		query  = ""
+		params map[string]interface{}
+	)
+
+	query = `
+		UPDATE
+			store
+		SET
+			name = :name,
+			updated_at = now()
+		WHERE store_id = :store_id
+	`
+
+	params = map[string]interface{}{
+		"store_id": id,
+		"name":     req.Name,
+	}
+
+	query, args := helper.ReplaceQueryParams(query, params)
+
+	rowsAffected, err := f.db.Exec(ctx, query, args...)
+	if isUniqueViolation(err) {
+		return 0, storage.ErrAlreadyExists
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func (f *storeRepo) Delete(ctx context.Context, req *models.StorePrimarKey) (int64, error) {
+
+	rowsAffected, err := f.db.Exec(ctx, "DELETE FROM store WHERE store_id = $1", req.Id)
+	if isForeignKeyViolation(err) {
+		return 0, storage.ErrInUse
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
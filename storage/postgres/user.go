@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+
+	"crud/models"
+	"crud/storage"
+)
+
+type userRepo struct {
+	db DB
+}
+
+func NewUserRepo(db DB) *userRepo {
+	return &userRepo{
+		db: db,
+	}
+}
+
+func (f *userRepo) Create(ctx context.Context, user *models.CreateUser) (string, error) {
+
+	var (
+		id    = uuid.New().String()
+		query string
+	)
+
+	if len(user.Roles) == 0 {
+		user.Roles = []string{"viewer"}
+	}
+
+	query = `
+		INSERT INTO users(
+			user_id,
+			email,
+			password_hash,
+			roles,
+			updated_at
+		) VALUES ( $1, LOWER($2), $3, $4, now() )
+	`
+
+	_, err := f.db.Exec(ctx, query,
+		id,
+		user.Email,
+		user.PasswordHash,
+		user.Roles,
+	)
+
+	if isUniqueViolation(err) {
+		return "", storage.ErrAlreadyExists
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (f *userRepo) GetByPKey(ctx context.Context, pkey *models.UserPrimarKey) (*models.User, error) {
+
+	query := `
+		SELECT
+			user_id,
+			email,
+			password_hash,
+			roles,
+			created_at,
+			updated_at
+		FROM
+			users
+		WHERE user_id = $1
+	`
+
+	return f.scanUser(f.db.QueryRow(ctx, query, pkey.Id))
+}
+
+func (f *userRepo) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+
+	query := `
+		SELECT
+			user_id,
+			email,
+			password_hash,
+			roles,
+			created_at,
+			updated_at
+		FROM
+			users
+		WHERE email = LOWER($1)
+	`
+
+	return f.scanUser(f.db.QueryRow(ctx, query, email))
+}
+
+func (f *userRepo) UpdatePassword(ctx context.Context, id string, passwordHash string) (int64, error) {
+
+	query := `
+		UPDATE
+			users
+		SET
+			password_hash = $2,
+			updated_at = now()
+		WHERE user_id = $1
+	`
+
+	rowsAffected, err := f.db.Exec(ctx, query, id, passwordHash)
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected.RowsAffected(), nil
+}
+
+func (f *userRepo) scanUser(row rowScanner) (*models.User, error) {
+
+	var (
+		id           sql.NullString
+		email        sql.NullString
+		passwordHash sql.NullString
+		roles        []string
+		createdAt    sql.NullString
+		updatedAt    sql.NullString
+	)
+
+	err := row.Scan(
+		&id,
+		&email,
+		&passwordHash,
+		&roles,
+		&createdAt,
+		&updatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.User{
+		Id:           id.String,
+		Email:        email.String,
+		Roles:        roles,
+		PasswordHash: passwordHash.String,
+		CreatedAt:    createdAt.String,
+		UpdatedAt:    updatedAt.String,
+	}, nil
+}
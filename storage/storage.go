@@ -2,37 +2,246 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"crud/models"
+	"crud/pkg/fee"
+)
+
+var (
+	ErrAlreadyExists     = errors.New("already exists")
+	ErrReferenceNotFound = errors.New("referenced row not found")
+	ErrInUse             = errors.New("still referenced")
+	ErrTokenExpired      = errors.New("token expired")
+	ErrTokenReused       = errors.New("token reused")
+
+	ErrInventoryUnavailable = errors.New("inventory item is not available")
+	ErrCustomerInactive     = errors.New("customer is inactive")
+	ErrAlreadyReturned      = errors.New("rental already returned")
+
+	ErrAmountExceedsDue        = errors.New("amount exceeds the outstanding balance of the rental")
+	ErrAmountExceedsRefundable = errors.New("amount exceeds the refundable rest of the payment")
+	ErrNotRefundable           = errors.New("a refund can not be refunded")
+
+	ErrManagerCycle  = errors.New("manager would report to the staff member")
+	ErrCategoryCycle = errors.New("category would be its own ancestor")
 )
 
 type StorageI interface {
 	CloseDB()
+	WithTx(ctx context.Context, fn func(tx StorageI) error) error
 	Film() FilmRepoI
 	Actor() ActorRepoI
 	Category() CategoryRepoI
+	Language() LanguageRepoI
+	Store() StoreRepoI
+	Inventory() InventoryRepoI
+	Country() CountryRepoI
+	City() CityRepoI
+	Address() AddressRepoI
+	Customer() CustomerRepoI
+	Staff() StaffRepoI
+	Rental() RentalRepoI
+	Payment() PaymentRepoI
+	ApiKey() ApiKeyRepoI
+	User() UserRepoI
+	RefreshToken() RefreshTokenRepoI
+	PasswordReset() PasswordResetRepoI
+	IdempotencyKey() IdempotencyKeyRepoI
+	Search() SearchRepoI
+	Report() ReportRepoI
+	Review() ReviewRepoI
+	Recommendation() RecommendationRepoI
 }
 
 type FilmRepoI interface {
 	Create(ctx context.Context, req *models.CreateFilm) (string, error)
 	GetByPKey(ctx context.Context, req *models.FilmPrimarKey) (*models.Film, error)
 	GetList(ctx context.Context, req *models.GetListFilmRequest) (*models.GetListFilmResponse, error)
+	Export(ctx context.Context, req *models.GetListFilmRequest, fn func(*models.Film) error) error
 	Update(ctx context.Context, id string, req *models.UpdateFilm) (int64, error)
-	Delete(ctx context.Context, req *models.FilmPrimarKey) error
+	Delete(ctx context.Context, req *models.FilmPrimarKey) ([]*models.MediaAsset, error)
+	Upsert(ctx context.Context, req *models.CreateFilm) (string, bool, error)
+	AddActor(ctx context.Context, req *models.FilmActorPrimarKey) error
+	RemoveActor(ctx context.Context, req *models.FilmActorPrimarKey) (int64, error)
+	GetActors(ctx context.Context, req *models.FilmPrimarKey) (*models.GetFilmActorsResponse, error)
+	AddCategory(ctx context.Context, req *models.FilmCategoryPrimarKey) error
+	RemoveCategory(ctx context.Context, req *models.FilmCategoryPrimarKey) (int64, error)
+	GetCategories(ctx context.Context, req *models.FilmPrimarKey) (*models.GetFilmCategoriesResponse, error)
+	SetPoster(ctx context.Context, id string, req *models.MediaAsset) (string, error)
+	SetTrailer(ctx context.Context, id string, req *models.MediaAsset) (string, error)
+	CreateMany(ctx context.Context, req []*models.CreateFilm, mode string) ([]*models.BatchResult, error)
+	DeleteMany(ctx context.Context, ids []string, mode string) ([]*models.BatchResult, []*models.MediaAsset, error)
 }
 
 type ActorRepoI interface {
 	Create(ctx context.Context, req *models.CreateActor) (string, error)
 	GetByPKey(ctx context.Context, req *models.ActorPrimarKey) (*models.Actor, error)
 	GetList(ctx context.Context, req *models.GetListActorRequest) (*models.GetListActorResponse, error)
+	Export(ctx context.Context, req *models.GetListActorRequest, fn func(*models.Actor) error) error
 	Update(ctx context.Context, id string, req *models.UpdateActor) (int64, error)
-	Delete(ctx context.Context, req *models.ActorPrimarKey) error
+	SetPhoto(ctx context.Context, id string, req *models.MediaAsset) (string, error)
+	Delete(ctx context.Context, req *models.ActorPrimarKey) ([]*models.MediaAsset, error)
+	Upsert(ctx context.Context, req *models.CreateActor) (string, bool, error)
+	FindSimilar(ctx context.Context, name string, threshold float32, limit int32) ([]*models.SimilarActor, error)
+	Merge(ctx context.Context, duplicateId string, req *models.MergeActor, mergedBy string) (*models.ActorMerge, []*models.MediaAsset, error)
+	GetMergedInto(ctx context.Context, req *models.ActorPrimarKey) (string, error)
+	CreateMany(ctx context.Context, req []*models.CreateActor, mode string) ([]*models.BatchResult, error)
+	DeleteMany(ctx context.Context, ids []string, mode string) ([]*models.BatchResult, []*models.MediaAsset, error)
 }
 
 type CategoryRepoI interface {
 	Create(ctx context.Context, req *models.CreateCategory) (string, error)
 	GetByPKey(ctx context.Context, req *models.CategoryPrimarKey) (*models.Category, error)
 	GetList(ctx context.Context, req *models.GetListCategoryRequest) (*models.GetListCategoryResponse, error)
+	Export(ctx context.Context, req *models.GetListCategoryRequest, fn func(*models.Category) error) error
 	Update(ctx context.Context, id string, req *models.UpdateCategory) (int64, error)
 	Delete(ctx context.Context, req *models.CategoryPrimarKey) error
+	Upsert(ctx context.Context, req *models.CreateCategory) (string, bool, error)
+	GetTree(ctx context.Context) (*models.GetCategoryTreeResponse, error)
+	GetDescendants(ctx context.Context, req *models.CategoryPrimarKey) (*models.GetCategoryDescendantsResponse, error)
+	CreateMany(ctx context.Context, req []*models.CreateCategory, mode string) ([]*models.BatchResult, error)
+	DeleteMany(ctx context.Context, ids []string, mode string) ([]*models.BatchResult, error)
+}
+
+type LanguageRepoI interface {
+	Create(ctx context.Context, req *models.CreateLanguage) (string, error)
+	GetByPKey(ctx context.Context, req *models.LanguagePrimarKey) (*models.Language, error)
+	GetList(ctx context.Context, req *models.GetListLanguageRequest) (*models.GetListLanguageResponse, error)
+	Update(ctx context.Context, id string, req *models.UpdateLanguage) (int64, error)
+	Delete(ctx context.Context, req *models.LanguagePrimarKey) (int64, error)
+}
+
+type StoreRepoI interface {
+	Create(ctx context.Context, req *models.CreateStore) (string, error)
+	GetByPKey(ctx context.Context, req *models.StorePrimarKey) (*models.Store, error)
+	GetList(ctx context.Context, req *models.GetListStoreRequest) (*models.GetListStoreResponse, error)
+	Update(ctx context.Context, id string, req *models.UpdateStore) (int64, error)
+	Delete(ctx context.Context, req *models.StorePrimarKey) (int64, error)
+}
+
+type InventoryRepoI interface {
+	Create(ctx context.Context, req *models.CreateInventory) (string, error)
+	GetByPKey(ctx context.Context, req *models.InventoryPrimarKey) (*models.Inventory, error)
+	GetList(ctx context.Context, req *models.GetListInventoryRequest) (*models.GetListInventoryResponse, error)
+	Update(ctx context.Context, id string, req *models.UpdateInventory) (int64, error)
+	Delete(ctx context.Context, req *models.InventoryPrimarKey) (int64, error)
+	CountByFilm(ctx context.Context, req *models.FilmPrimarKey) (*models.GetFilmInventoryResponse, error)
+	CountByStore(ctx context.Context, req *models.GetStoreInventoryRequest) (*models.GetStoreInventoryResponse, error)
+}
+
+type CountryRepoI interface {
+	Create(ctx context.Context, req *models.CreateCountry) (string, error)
+	GetByPKey(ctx context.Context, req *models.CountryPrimarKey) (*models.Country, error)
+	GetList(ctx context.Context, req *models.GetListCountryRequest) (*models.GetListCountryResponse, error)
+	Update(ctx context.Context, id string, req *models.UpdateCountry) (int64, error)
+	Delete(ctx context.Context, req *models.CountryPrimarKey) (int64, error)
+}
+
+type CityRepoI interface {
+	Create(ctx context.Context, req *models.CreateCity) (string, error)
+	GetByPKey(ctx context.Context, req *models.CityPrimarKey) (*models.City, error)
+	GetList(ctx context.Context, req *models.GetListCityRequest) (*models.GetListCityResponse, error)
+	Update(ctx context.Context, id string, req *models.UpdateCity) (int64, error)
+	Delete(ctx context.Context, req *models.CityPrimarKey) (int64, error)
+}
+
+type AddressRepoI interface {
+	Create(ctx context.Context, req *models.CreateAddress) (string, error)
+	Update(ctx context.Context, id string, req *models.CreateAddress) (int64, error)
+}
+
+type CustomerRepoI interface {
+	Create(ctx context.Context, req *models.CreateCustomer) (string, error)
+	GetByPKey(ctx context.Context, req *models.CustomerPrimarKey) (*models.Customer, error)
+	GetList(ctx context.Context, req *models.GetListCustomerRequest) (*models.GetListCustomerResponse, error)
+	Update(ctx context.Context, id string, req *models.UpdateCustomer) (int64, error)
+	Delete(ctx context.Context, req *models.CustomerPrimarKey) (int64, error)
+}
+
+type StaffRepoI interface {
+	Create(ctx context.Context, req *models.CreateStaff) (string, error)
+	GetByPKey(ctx context.Context, req *models.StaffPrimarKey) (*models.Staff, error)
+	GetByUserId(ctx context.Context, userId string) (*models.Staff, error)
+	GetList(ctx context.Context, req *models.GetListStaffRequest) (*models.GetListStaffResponse, error)
+	Update(ctx context.Context, id string, req *models.UpdateStaff) (int64, error)
+	Delete(ctx context.Context, req *models.StaffPrimarKey) (int64, error)
+}
+
+type RentalRepoI interface {
+	Checkout(ctx context.Context, req *models.CreateRental) (string, error)
+	Return(ctx context.Context, req *models.RentalPrimarKey, staffId string, policy fee.Policy) error
+	GetByPKey(ctx context.Context, req *models.RentalPrimarKey) (*models.Rental, error)
+	GetList(ctx context.Context, req *models.GetListRentalRequest) (*models.GetListRentalResponse, error)
+}
+
+type PaymentRepoI interface {
+	Create(ctx context.Context, req *models.CreatePayment) (string, error)
+	Refund(ctx context.Context, pkey *models.PaymentPrimarKey, req *models.RefundPayment) (string, error)
+	GetByPKey(ctx context.Context, req *models.PaymentPrimarKey) (*models.Payment, error)
+	GetList(ctx context.Context, req *models.GetListPaymentRequest) (*models.GetListPaymentResponse, error)
+	GetBalance(ctx context.Context, req *models.CustomerPrimarKey) (*models.CustomerBalance, error)
+}
+
+type ApiKeyRepoI interface {
+	Create(ctx context.Context, req *models.CreateApiKey) (string, error)
+	GetByPKey(ctx context.Context, req *models.ApiKeyPrimarKey) (*models.ApiKey, error)
+	GetActiveByHash(ctx context.Context, hash string) (*models.ApiKey, error)
+	GetList(ctx context.Context, req *models.GetListApiKeyRequest) (*models.GetListApiKeyResponse, error)
+	Revoke(ctx context.Context, req *models.ApiKeyPrimarKey) (int64, error)
+	TouchLastUsed(ctx context.Context, req *models.ApiKeyPrimarKey) error
+}
+
+type UserRepoI interface {
+	Create(ctx context.Context, req *models.CreateUser) (string, error)
+	GetByPKey(ctx context.Context, req *models.UserPrimarKey) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	UpdatePassword(ctx context.Context, id string, passwordHash string) (int64, error)
+}
+
+type RefreshTokenRepoI interface {
+	Create(ctx context.Context, userId string, tokenHash string, ttl time.Duration) error
+	Rotate(ctx context.Context, tokenHash string, newTokenHash string, ttl time.Duration) (string, error)
+	Revoke(ctx context.Context, tokenHash string) (int64, error)
+	RevokeAllByUser(ctx context.Context, userId string) error
+}
+
+type PasswordResetRepoI interface {
+	Create(ctx context.Context, userId string, tokenHash string, ttl time.Duration) error
+	Consume(ctx context.Context, tokenHash string) (string, error)
+}
+
+type IdempotencyKeyRepoI interface {
+	Reserve(ctx context.Context, req *models.IdempotencyKeyPrimarKey, requestHash string, ttl time.Duration) (*models.IdempotencyKey, bool, error)
+	GetByPKey(ctx context.Context, req *models.IdempotencyKeyPrimarKey) (*models.IdempotencyKey, error)
+	Complete(ctx context.Context, req *models.IdempotencyKeyPrimarKey, statusCode int, responseBody []byte) error
+	Release(ctx context.Context, req *models.IdempotencyKeyPrimarKey) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type SearchRepoI interface {
+	Search(ctx context.Context, req *models.SearchRequest) (*models.SearchResponse, error)
+}
+
+type ReviewRepoI interface {
+	Create(ctx context.Context, req *models.CreateReview) (string, error)
+	GetByPKey(ctx context.Context, req *models.ReviewPrimarKey) (*models.Review, error)
+	GetList(ctx context.Context, req *models.GetListReviewRequest) (*models.GetListReviewResponse, error)
+	SetStatus(ctx context.Context, id string, req *models.UpdateReviewStatus) (int64, error)
+	Vote(ctx context.Context, req *models.ReviewVote) error
+}
+
+type RecommendationRepoI interface {
+	Similar(ctx context.Context, req *models.GetSimilarFilmsRequest) (*models.GetSimilarFilmsResponse, error)
+	ForCustomer(ctx context.Context, req *models.GetRecommendationsRequest) (*models.GetRecommendationsResponse, error)
+	Refresh(ctx context.Context) error
+}
+
+type ReportRepoI interface {
+	TopFilms(ctx context.Context, req *models.GetTopFilmsRequest) (*models.GetTopFilmsResponse, error)
+	CategoryRevenue(ctx context.Context) (*models.GetCategoryRevenueResponse, error)
+	ActorPopularity(ctx context.Context, req *models.GetActorPopularityRequest) (*models.GetActorPopularityResponse, error)
+	StoreRevenue(ctx context.Context, req *models.GetStoreRevenueRequest) (*models.GetStoreRevenueResponse, error)
+	Refresh(ctx context.Context) error
 }